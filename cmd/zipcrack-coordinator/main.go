@@ -0,0 +1,118 @@
+// Command zipcrack-coordinator runs the generator and stats side of a distributed crack: it
+// listens for zipcrack-worker processes, publishes candidate batches to whichever workers connect,
+// and drives the same TUI cmd/zipcrack uses locally, fed by attempt counts aggregated from remote
+// WorkerResults instead of local goroutines.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"zipcrack/internal/charset"
+	"zipcrack/internal/cracker"
+	"zipcrack/internal/transport"
+	"zipcrack/internal/tui"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func main() {
+	zipPath := flag.String("zip", "", "path to the target ZIP file (required)")
+	listenAddr := flag.String("listen", ":9631", "address to listen on for zipcrack-worker connections")
+	prefetch := flag.Int("prefetch", 4, "sizes the batch queue kept ahead of demand (prefetch*8 batches, shared by all worker connections)")
+	expectWorkers := flag.Int("expect-workers", 16, "expected number of zipcrack-worker processes, for sizing the TUI's per-worker throughput display")
+	batchSize := flag.Int("batch", 8192, "candidate passwords per published batch")
+	minLen := flag.Int("minlen", 1, "minimum password length")
+	maxLen := flag.Int("maxlen", 8, "maximum password length")
+	useLetters := flag.Bool("letters", true, "include letters (a-zA-Z)")
+	useNumbers := flag.Bool("numbers", true, "include numbers (0-9)")
+	useSpecialCommon := flag.Bool("special", true, "include common special characters (!@#$%^&*_-)")
+	useSpecialAll := flag.Bool("special-all", false, "include all ASCII punctuation")
+	reportEvery := flag.Duration("report-every", 2*time.Second, "stats sampling interval")
+	flag.Parse()
+
+	if *zipPath == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if !*useLetters && !*useNumbers && !*useSpecialCommon && !*useSpecialAll {
+		fmt.Println("No character sets selected, enabling letters by default.")
+		*useLetters = true
+	}
+
+	var sets [][]rune
+	if *useLetters {
+		sets = append(sets, charset.Letters())
+	}
+	if *useNumbers {
+		sets = append(sets, charset.Digits())
+	}
+	if *useSpecialCommon {
+		sets = append(sets, charset.SpecialCommon())
+	}
+	if *useSpecialAll {
+		sets = append(sets, charset.SpecialAll())
+	}
+	alphabet := charset.Combine(sets...)
+
+	zipBytes, err := os.ReadFile(*zipPath)
+	if err != nil {
+		log.Fatalf("failed to read zip: %v", err)
+	}
+
+	tr, err := transport.NewTCPCoordinator(*listenAddr, *prefetch)
+	if err != nil {
+		log.Fatalf("failed to start coordinator transport: %v", err)
+	}
+	defer tr.Close()
+	fmt.Printf("Listening for workers on %s\n", *listenAddr)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cfg := cracker.Config{
+		ZipBytes:      zipBytes,
+		Alphabet:      alphabet,
+		MinLen:        *minLen,
+		MaxLen:        *maxLen,
+		BatchSize:     *batchSize,
+		ReportEvery:   *reportEvery,
+		FoundCallback: func(pw string) { cancel() },
+		Transport:     tr,
+	}
+	run, err := cracker.NewRunner(cfg)
+	if err != nil {
+		log.Fatalf("failed to init runner: %v", err)
+	}
+
+	model := tui.NewModel(tui.Config{
+		Workers:     *expectWorkers,
+		SampleEvery: cfg.ReportEvery,
+		StatsCh:     run.StatsCh(),
+		ResultCh:    run.ResultCh(),
+		Stop:        cancel,
+	})
+
+	go func() {
+		if err := run.Start(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("runner error: %v", err)
+			cancel()
+		}
+	}()
+
+	if _, err := tea.NewProgram(model).Run(); err != nil {
+		log.Fatalf("tui error: %v", err)
+	}
+
+	res := run.GetResult()
+	if res.Found {
+		fmt.Printf("\nPassword found: %s\n", res.Password)
+	} else {
+		fmt.Println("\nPassword not found or operation cancelled.")
+	}
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"bufio"
 	"context"
+	"flag"
 	"fmt"
 	"log"
 	"math/rand"
@@ -12,6 +13,8 @@ import (
 	"strings"
 	"time"
 
+	"zipcrack/internal/archive"
+	"zipcrack/internal/candidates"
 	"zipcrack/internal/charset"
 	"zipcrack/internal/cracker"
 	"zipcrack/internal/tui"
@@ -61,6 +64,10 @@ func promptInt(r *bufio.Reader, label string, def int) int {
 }
 
 func main() {
+	checkpointPath := flag.String("checkpoint-path", "", "path to periodically save/restore cracking progress, for pause/resume across restarts")
+	resume := flag.Bool("resume", false, "resume from --checkpoint-path if it matches the target ZIP, instead of starting fresh")
+	flag.Parse()
+
 	_ = rand.New(rand.NewSource(time.Now().UnixNano()))
 	reader := bufio.NewReader(os.Stdin)
 
@@ -114,25 +121,147 @@ func main() {
 	}
 	alphabet := charset.Combine(sets...)
 
-	// Load ZIP file into memory
+	var source cracker.CandidateSource
+	if promptYesNo(reader, "Crack from a wordlist instead of pure brute force?", false) {
+		wordlistPath := promptString(reader, "Wordlist path", "")
+		if promptYesNo(reader, "Apply mutation rules (case flips, inserts/deletes, suffixes)?", true) {
+			words, werr := cracker.LoadWordlist(wordlistPath)
+			if werr != nil {
+				log.Fatalf("failed to read wordlist: %v", werr)
+			}
+			source = cracker.NewMutatorSource(words, alphabet, minLen, maxLen, 3)
+		} else {
+			ws, werr := cracker.NewWordlistSource(wordlistPath)
+			if werr != nil {
+				log.Fatalf("failed to read wordlist: %v", werr)
+			}
+			source = ws
+		}
+	} else if promptYesNo(reader, "Use Markov-model generation trained on a wordlist (favors realistic-looking guesses)?", false) {
+		corpusPath := promptString(reader, "Training wordlist path", "")
+		order := promptInt(reader, "Markov chain order (context length in characters)", 1)
+		ms, merr := cracker.NewMarkovSource(corpusPath, order, minLen, maxLen)
+		if merr != nil {
+			log.Fatalf("failed to train markov source: %v", merr)
+		}
+		source = ms
+	} else if promptYesNo(reader, "Use Zipf-weighted character sampling (favors common characters, no wordlist needed)?", false) {
+		source = cracker.NewZipfSource(alphabet, minLen, maxLen, 1.1, 2.7)
+	}
+
+	// loadCandidateCheckpoint returns a previously saved candidates.Checkpoint of the given kind,
+	// or the zero Checkpoint if --resume wasn't requested or none matches - callers fall back to
+	// starting that generator fresh in either case.
+	loadCandidateCheckpoint := func(kind string) (candidates.Checkpoint, bool) {
+		if !*resume || *checkpointPath == "" {
+			return candidates.Checkpoint{}, false
+		}
+		cp, err := candidates.LoadCheckpoint(*checkpointPath)
+		if err != nil || cp.Kind != kind {
+			return candidates.Checkpoint{}, false
+		}
+		return cp, true
+	}
+
+	var gen candidates.Generator
+	if source == nil && promptYesNo(reader, "Use a mask-based attack (hashcat-style pattern, e.g. ?u?l?l?l?d?d)?", false) {
+		pattern := promptString(reader, "Mask pattern", "?l?l?l?l?l?l?d?d")
+		mask, merr := charset.ParseMask(pattern)
+		if merr != nil {
+			log.Fatalf("invalid mask: %v", merr)
+		}
+		if promptYesNo(reader, "Combine with a dictionary (hybrid: word+mask suffix)?", false) {
+			wordlistPath := promptString(reader, "Wordlist path", "")
+			words, werr := cracker.LoadWordlist(wordlistPath)
+			if werr != nil {
+				log.Fatalf("failed to read wordlist: %v", werr)
+			}
+			var g *candidates.HybridGenerator
+			var gerr error
+			if cp, ok := loadCandidateCheckpoint("hybrid"); ok {
+				fmt.Printf("Resuming hybrid attack from checkpoint %s\n", *checkpointPath)
+				g, gerr = candidates.NewHybridGeneratorFromCheckpoint(words, mask, cp)
+			} else {
+				g, gerr = candidates.NewHybridGenerator(words, mask)
+			}
+			if gerr != nil {
+				log.Fatalf("failed to init hybrid generator: %v", gerr)
+			}
+			gen = g
+		} else {
+			var g *candidates.MaskGenerator
+			var gerr error
+			if cp, ok := loadCandidateCheckpoint("mask"); ok {
+				fmt.Printf("Resuming mask attack from checkpoint %s\n", *checkpointPath)
+				g, gerr = candidates.NewMaskGeneratorFromCheckpoint(mask, cp)
+			} else {
+				g, gerr = candidates.NewMaskGenerator(mask)
+			}
+			if gerr != nil {
+				log.Fatalf("failed to init mask generator: %v", gerr)
+			}
+			gen = g
+		}
+	} else if source == nil && promptYesNo(reader, "Use a dictionary with hashcat-style rules (capitalize/reverse/leet/digit suffixes) instead of pure brute force?", false) {
+		wordlistPath := promptString(reader, "Wordlist path", "")
+		words, werr := cracker.LoadWordlist(wordlistPath)
+		if werr != nil {
+			log.Fatalf("failed to read wordlist: %v", werr)
+		}
+		rules := charset.Rules{
+			Capitalize:   promptYesNo(reader, "Rule: capitalize first letter?", true),
+			Reverse:      promptYesNo(reader, "Rule: reverse?", false),
+			Leet:         promptYesNo(reader, "Rule: leetspeak substitution?", true),
+			AppendDigits: promptYesNo(reader, "Rule: append two-digit suffixes (00-99)?", false),
+		}
+		if cp, ok := loadCandidateCheckpoint("dictionary"); ok {
+			fmt.Printf("Resuming dictionary attack from checkpoint %s\n", *checkpointPath)
+			gen = candidates.NewDictionaryGeneratorFromCheckpoint(words, rules, cp)
+		} else {
+			gen = candidates.NewDictionaryGenerator(words, rules)
+		}
+	}
+
+	// Load archive file into memory
 	zipBytes, err := os.ReadFile(zipPath)
 	if err != nil {
 		log.Fatalf("failed to read zip: %v", err)
 	}
 
+	// RAR5 and 7z have no GPU kernel yet (see archive.ArchiveVerifier's KernelSPIRV doc), so they
+	// run a plain CPU brute-force loop here instead of going through cracker.Runner's GPU-fed
+	// pipeline below; wiring them into that pipeline is future work.
+	if format := archive.DetectFormat(zipBytes); format != archive.FormatZip && format != archive.FormatUnknown {
+		fmt.Printf("Detected %s archive, cracking on CPU (%d workers)...\n", format, workers)
+		pw, found, err := archive.Crack(zipBytes, cracker.BruteForceSource{Alphabet: alphabet, MinLen: minLen, MaxLen: maxLen}, workers)
+		if err != nil {
+			log.Fatalf("archive crack failed: %v", err)
+		}
+		if found {
+			fmt.Printf("\nPassword found: %s\n", pw)
+		} else {
+			fmt.Println("\nPassword not found or operation cancelled.")
+		}
+		return
+	}
+
 	// Prepare cracking runner
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
 	cfg := cracker.Config{
-		ZipBytes:      zipBytes,
-		Alphabet:      alphabet,
-		MinLen:        minLen,
-		MaxLen:        maxLen,
-		Workers:       workers,
-		BatchSize:     batchSize,
-		ReportEvery:   2 * time.Second,
-		FoundCallback: func(pw string) { cancel() },
+		ZipBytes:       zipBytes,
+		Alphabet:       alphabet,
+		MinLen:         minLen,
+		MaxLen:         maxLen,
+		Workers:        workers,
+		BatchSize:      batchSize,
+		ReportEvery:    2 * time.Second,
+		FoundCallback:  func(pw string) { cancel() },
+		Source:         source,
+		Candidates:     gen,
+		CheckpointPath: *checkpointPath,
+		Resume:         *resume,
 	}
 	run, err := cracker.NewRunner(cfg)
 	if err != nil {
@@ -141,11 +270,12 @@ func main() {
 
 	// TUI model
 	model := tui.NewModel(tui.Config{
-		Workers:     workers,
-		SampleEvery: cfg.ReportEvery,
-		StatsCh:     run.StatsCh(),
-		ResultCh:    run.ResultCh(),
-		Stop:        cancel,
+		Workers:        workers,
+		SampleEvery:    cfg.ReportEvery,
+		StatsCh:        run.StatsCh(),
+		ResultCh:       run.ResultCh(),
+		Stop:           cancel,
+		InitialElapsed: run.InitialElapsed(),
 	})
 
 	// Start cracking in background
@@ -0,0 +1,118 @@
+// Command zipknown runs a known-plaintext attack against a traditional ZipCrypto encrypted ZIP
+// entry: given some bytes of content the attacker already knows are in the decompressed entry, it
+// recovers the cipher's internal key2 register without ever trying a password, and (for short
+// passwords starting from the stream's first byte) the password itself.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+
+	yzip "github.com/yeka/zip"
+
+	"zipcrack/internal/charset"
+	"zipcrack/internal/plaintext"
+	"zipcrack/internal/verifier"
+)
+
+func main() {
+	zipPath := flag.String("zip", "", "path to the target ZIP file (required)")
+	plaintextPath := flag.String("plaintext", "", "path to a file containing the known plaintext bytes (required)")
+	index := flag.Int("index", -1, "ordinal index of the encrypted entry to attack (default: smallest traditional-ZipCrypto entry)")
+	offset := flag.Int("offset", 0, "byte offset of the known plaintext within the entry's raw data stream, counting from the start of the 12-byte encryption header")
+	maxLen := flag.Int("maxlen", 0, "if > 0, also brute force passwords up to this length against the recovered key (only valid when offset is 0)")
+	flag.Parse()
+
+	if *zipPath == "" || *plaintextPath == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	zipBytes, err := os.ReadFile(*zipPath)
+	if err != nil {
+		log.Fatalf("failed to read zip: %v", err)
+	}
+	knownPlaintext, err := os.ReadFile(*plaintextPath)
+	if err != nil {
+		log.Fatalf("failed to read plaintext file: %v", err)
+	}
+
+	targetIndex := *index
+	if targetIndex < 0 {
+		targetIndex, err = smallestZipCryptoEntry(zipBytes)
+		if err != nil {
+			log.Fatalf("failed to locate a ZipCrypto entry: %v", err)
+		}
+	}
+
+	ciphertext, err := verifier.ExtractCiphertext(zipBytes, targetIndex, *offset, len(knownPlaintext))
+	if err != nil {
+		log.Fatalf("failed to extract ciphertext: %v", err)
+	}
+
+	attack, err := plaintext.NewAttack(ciphertext, knownPlaintext)
+	if err != nil {
+		log.Fatalf("failed to set up attack: %v", err)
+	}
+
+	key2, ok := attack.RecoverKey2()
+	if !ok {
+		fmt.Println("key2 did not converge to a unique candidate; try a longer known-plaintext window.")
+		os.Exit(1)
+	}
+	fmt.Printf("recovered key2: 0x%08x\n", key2)
+
+	if *maxLen <= 0 {
+		return
+	}
+	if *offset != 0 {
+		fmt.Println("password recovery requires offset=0 (known plaintext must start at the first byte of the 12-byte encryption header); skipping.")
+		return
+	}
+
+	alphabet := charset.Combine(charset.Letters(), charset.Digits(), charset.SpecialCommon())
+	charsetBytes := make([]byte, len(alphabet))
+	for i, r := range alphabet {
+		charsetBytes[i] = byte(r)
+	}
+
+	if password, ok := attack.RecoverPassword(charsetBytes, *maxLen); ok {
+		fmt.Printf("recovered password: %s\n", password)
+	} else {
+		fmt.Println("password not found within maxlen.")
+	}
+}
+
+// smallestZipCryptoEntry mirrors verifier.findSmallestEncryptedIndex's target-selection logic,
+// scoped down to just traditional ZipCrypto entries since that's all this attack supports.
+func smallestZipCryptoEntry(zipBytes []byte) (int, error) {
+	br := bytes.NewReader(zipBytes)
+	zr, err := yzip.NewReader(br, int64(len(zipBytes)))
+	if err != nil {
+		return 0, err
+	}
+
+	type entry struct {
+		index int
+		size  uint64
+	}
+	var candidates []entry
+	for i, f := range zr.File {
+		if f.FileInfo().IsDir() || !f.IsEncrypted() {
+			continue
+		}
+		if _, err := verifier.ParseZipCryptoInfoAt(zipBytes, i); err != nil {
+			continue
+		}
+		candidates = append(candidates, entry{index: i, size: f.UncompressedSize64})
+	}
+	if len(candidates) == 0 {
+		return 0, fmt.Errorf("no traditional ZipCrypto entries found")
+	}
+	sort.Slice(candidates, func(a, b int) bool { return candidates[a].size < candidates[b].size })
+	return candidates[0].index, nil
+}
@@ -0,0 +1,85 @@
+package archive
+
+import (
+	"errors"
+	"sync"
+
+	"zipcrack/internal/charset"
+)
+
+// Crack drives src against an ArchiveVerifier for archiveBytes' detected format across workers
+// goroutines, stopping as soon as one of them confirms a match. It is the CPU counterpart to
+// cracker.Runner - ArchiveVerifier has no GPU kernel wired up yet (KernelSPIRV names a shader asset
+// that doesn't exist), so this is the only way to exercise RAR5Verifier/SevenZipVerifier today;
+// ZIP archives should keep using cracker.Runner, which already has the faster GPU path.
+func Crack(archiveBytes []byte, src charset.Source, workers int) (string, bool, error) {
+	format := DetectFormat(archiveBytes)
+	if format == FormatZip {
+		return "", false, errors.New("archive: use cracker.Runner for ZIP archives")
+	}
+	v, err := NewVerifier(format)
+	if err != nil {
+		return "", false, err
+	}
+	header, err := v.PrepareHeader(archiveBytes)
+	if err != nil {
+		return "", false, err
+	}
+	if workers <= 0 {
+		workers = 1
+	}
+
+	jobs := make(chan string, workers*64)
+	found := make(chan string, 1)
+	stop := make(chan struct{})
+	var stopOnce sync.Once
+	signalStop := func() { stopOnce.Do(func() { close(stop) }) }
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				case pw, ok := <-jobs:
+					if !ok {
+						return
+					}
+					ok, err := v.VerifyResult(pw, header)
+					if err != nil || !ok {
+						continue
+					}
+					select {
+					case found <- pw:
+					default:
+					}
+					signalStop()
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		defer close(jobs)
+		src.Generate(func(pw string) bool {
+			select {
+			case <-stop:
+				return false
+			case jobs <- pw:
+				return true
+			}
+		})
+	}()
+
+	wg.Wait()
+	select {
+	case pw := <-found:
+		return pw, true, nil
+	default:
+		return "", false, nil
+	}
+}
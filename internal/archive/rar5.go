@@ -0,0 +1,208 @@
+package archive
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+)
+
+// RAR5 block types, from the format's vint-prefixed header stream (rarlab's rar5 spec section
+// "Archive encryption header"). Only the handful of fields PrepareHeader needs are read here; a
+// full extractor would also walk file headers to recover per-file CRCs etc.
+const (
+	rar5BlockTypeMain   = 1
+	rar5BlockTypeCrypt  = 4
+	rar5EncryptAES256   = 0
+	rar5CheckValueFlag  = 0x01
+	rar5SaltLen         = 16
+	rar5CheckValueLen   = 12
+	rar5HeaderLenOffset = rar5HeaderMagicLen
+	rar5HeaderMagicLen  = 8
+)
+
+// RAR5Header is the PBKDF2-HMAC-SHA256 key-derivation parameters and optional password check
+// value parsed from a RAR5 archive's encryption header block.
+type RAR5Header struct {
+	// KDFCount is RAR5's lg2count field: the PBKDF2 iteration count is 1<<(KDFCount+1) per the
+	// format spec's "count = 1 << (header.kdf_count+1)" reference definition.
+	KDFCount int
+	Salt     [rar5SaltLen]byte
+	// HasCheckValue reports whether Check holds a valid password check value (archives created
+	// without "store password check" leave this absent, forcing a full decrypt to verify).
+	HasCheckValue bool
+	Check         [rar5CheckValueLen]byte
+}
+
+// RAR5Verifier implements ArchiveVerifier for RAR5's AES-256 archive encryption.
+type RAR5Verifier struct{}
+
+// ParseRAR5Header walks the header blocks that follow the RAR5 signature until it finds the
+// encryption header block (type rar5BlockTypeCrypt), and returns its KDF parameters. Each block
+// is skipped by its header size alone; a block's separate data area is not skipped.
+func ParseRAR5Header(archiveBytes []byte) (*RAR5Header, error) {
+	if len(archiveBytes) < rar5HeaderMagicLen+16 {
+		return nil, errors.New("archive: rar5 file too small")
+	}
+	if DetectFormat(archiveBytes) != FormatRAR5 {
+		return nil, errors.New("archive: not a RAR5 archive")
+	}
+	// Walk header blocks starting just past the 8-byte signature. Each block starts with a
+	// 4-byte CRC32 (ignored here) followed by vint-encoded header size, then vint-encoded header
+	// type, then type-specific fields.
+	pos := rar5HeaderMagicLen
+	for pos+9 < len(archiveBytes) {
+		pos += 4 // skip header CRC32
+		headerSize, n, err := readVint(archiveBytes[pos:])
+		if err != nil {
+			return nil, err
+		}
+		blockStart := pos + n
+		blockEnd := blockStart + int(headerSize)
+		if blockEnd > len(archiveBytes) {
+			return nil, errors.New("archive: truncated rar5 header block")
+		}
+		headerType, tn, err := readVint(archiveBytes[blockStart:blockEnd])
+		if err != nil {
+			return nil, err
+		}
+		body := archiveBytes[blockStart+tn : blockEnd]
+		if headerType == rar5BlockTypeCrypt {
+			return parseRAR5CryptBlock(body)
+		}
+		pos = blockEnd
+	}
+	return nil, errors.New("archive: no encryption header found (archive isn't password protected)")
+}
+
+// parseRAR5CryptBlock reads the CRYPT header body: header CRC flags vint, encryption version
+// vint, encryption flags vint, KDF count byte, 16-byte salt, and (if the check-value flag is
+// set) a 12-byte password check value.
+func parseRAR5CryptBlock(body []byte) (*RAR5Header, error) {
+	pos := 0
+	_, n, err := readVint(body[pos:]) // header flags
+	if err != nil {
+		return nil, err
+	}
+	pos += n
+	_, n, err = readVint(body[pos:]) // encryption version (must be 0 == AES-256)
+	if err != nil {
+		return nil, err
+	}
+	pos += n
+	flags, n, err := readVint(body[pos:])
+	if err != nil {
+		return nil, err
+	}
+	pos += n
+	if pos >= len(body) {
+		return nil, errors.New("archive: truncated rar5 crypt header")
+	}
+	kdfCount := int(body[pos])
+	pos++
+	if pos+rar5SaltLen > len(body) {
+		return nil, errors.New("archive: truncated rar5 crypt header salt")
+	}
+	h := &RAR5Header{KDFCount: kdfCount}
+	copy(h.Salt[:], body[pos:pos+rar5SaltLen])
+	pos += rar5SaltLen
+	if flags&rar5CheckValueFlag != 0 && pos+rar5CheckValueLen <= len(body) {
+		h.HasCheckValue = true
+		copy(h.Check[:], body[pos:pos+rar5CheckValueLen])
+	}
+	return h, nil
+}
+
+// readVint decodes a RAR5 variable-length integer: 7 data bits per byte, low-to-high, continuing
+// while the high bit is set. It returns the value, the number of bytes consumed, and an error if
+// buf runs out before a terminating byte.
+func readVint(buf []byte) (value uint64, n int, err error) {
+	for n = 0; n < len(buf) && n < 10; n++ {
+		b := buf[n]
+		value |= uint64(b&0x7f) << (7 * n)
+		if b&0x80 == 0 {
+			return value, n + 1, nil
+		}
+	}
+	return 0, 0, errors.New("archive: malformed rar5 vint")
+}
+
+// PrepareHeader implements ArchiveVerifier by parsing and re-packing a RAR5Header as
+// {kdfCount(u32), salt[16], hasCheck(u32), check[12]} = 36 bytes, little-endian.
+func (v *RAR5Verifier) PrepareHeader(archiveBytes []byte) ([]byte, error) {
+	h, err := ParseRAR5Header(archiveBytes)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]byte, 4+rar5SaltLen+4+rar5CheckValueLen)
+	binary.LittleEndian.PutUint32(out[0:4], uint32(h.KDFCount))
+	copy(out[4:4+rar5SaltLen], h.Salt[:])
+	hasCheck := uint32(0)
+	if h.HasCheckValue {
+		hasCheck = 1
+	}
+	binary.LittleEndian.PutUint32(out[4+rar5SaltLen:8+rar5SaltLen], hasCheck)
+	copy(out[8+rar5SaltLen:], h.Check[:])
+	return out, nil
+}
+
+// KernelSPIRV names the (not yet checked in, see verifier.shaderVariants' own zipcrack_aes*.spv
+// entries for precedent) compute shader that verifies RAR5 passwords in batch.
+func (v *RAR5Verifier) KernelSPIRV() string {
+	return "shaders/archive_rar5.spv"
+}
+
+// VerifyResult re-derives RAR5Header from header and checks password via RAR5's PBKDF2-HMAC-SHA256
+// key derivation against the stored password check value. If the archive has no check value,
+// VerifyResult returns an error: a definitive answer would require decrypting and CRC-checking a
+// file, which this verifier doesn't do.
+func (v *RAR5Verifier) VerifyResult(password string, header []byte) (bool, error) {
+	if len(header) != 4+rar5SaltLen+4+rar5CheckValueLen {
+		return false, errors.New("archive: malformed rar5 header buffer")
+	}
+	kdfCount := binary.LittleEndian.Uint32(header[0:4])
+	salt := header[4 : 4+rar5SaltLen]
+	hasCheck := binary.LittleEndian.Uint32(header[4+rar5SaltLen:8+rar5SaltLen]) != 0
+	check := header[8+rar5SaltLen:]
+	if !hasCheck {
+		return false, errors.New("archive: rar5 header has no password check value to verify against")
+	}
+
+	iterations := 1 << (kdfCount + 1)
+	// The password check value is derived by continuing the PBKDF2-HMAC-SHA256 chain for 16 more
+	// rounds past the main key's iteration count, hashing that 32-byte value with SHA-256, and
+	// comparing the first 8 bytes of the hash with the first 8 bytes of the stored check value.
+	derived := pbkdf2HMACSHA256(password, salt, iterations+16, 32)
+	sum := sha256.Sum256(derived)
+	return hmac.Equal(sum[:8], check[:8]), nil
+}
+
+// pbkdf2HMACSHA256 implements RFC 8018 PBKDF2 with HMAC-SHA256 as the PRF.
+func pbkdf2HMACSHA256(password string, salt []byte, iter, dkLen int) []byte {
+	mac := hmac.New(sha256.New, []byte(password))
+	hLen := mac.Size()
+	numBlocks := (dkLen + hLen - 1) / hLen
+
+	dk := make([]byte, numBlocks*hLen)
+	var blockIdx [4]byte
+	for b := 1; b <= numBlocks; b++ {
+		mac.Reset()
+		mac.Write(salt)
+		binary.BigEndian.PutUint32(blockIdx[:], uint32(b))
+		mac.Write(blockIdx[:])
+		u := mac.Sum(nil)
+
+		t := dk[(b-1)*hLen : b*hLen]
+		copy(t, u)
+
+		for i := 1; i < iter; i++ {
+			mac.Reset()
+			mac.Write(u)
+			u = mac.Sum(u[:0])
+			for j := range t {
+				t[j] ^= u[j]
+			}
+		}
+	}
+	return dk[:dkLen]
+}
@@ -0,0 +1,202 @@
+package archive
+
+import (
+	"testing"
+
+	"crypto/sha256"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+func TestReadVint(t *testing.T) {
+	cases := []struct {
+		name    string
+		buf     []byte
+		value   uint64
+		n       int
+		wantErr bool
+	}{
+		{"single byte zero", []byte{0x00}, 0, 1, false},
+		{"single byte max 7 bits", []byte{0x7f}, 0x7f, 1, false},
+		{"two byte continuation", []byte{0x80, 0x01}, 0x80, 2, false},
+		{"three byte continuation", []byte{0xff, 0xff, 0x03}, 0xffff, 3, false},
+		{"trailing bytes ignored", []byte{0x21, 0xaa, 0xbb}, 0x21, 1, false},
+		{"truncated continuation", []byte{0x80}, 0, 0, true},
+		{"empty buffer", []byte{}, 0, 0, true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			value, n, err := readVint(c.buf)
+			if c.wantErr {
+				if err == nil {
+					t.Fatalf("readVint(%v) = %d, %d, nil, want error", c.buf, value, n)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("readVint(%v) unexpected error: %v", c.buf, err)
+			}
+			if value != c.value || n != c.n {
+				t.Fatalf("readVint(%v) = %d, %d, want %d, %d", c.buf, value, n, c.value, c.n)
+			}
+		})
+	}
+}
+
+func TestPBKDF2HMACSHA256MatchesReferenceImplementation(t *testing.T) {
+	password := "hunter2"
+	salt := []byte("some-salt-bytes-")
+	iter := 37
+	dkLen := 48
+
+	got := pbkdf2HMACSHA256(password, salt, iter, dkLen)
+	want := pbkdf2.Key([]byte(password), salt, iter, dkLen, sha256.New)
+
+	if string(got) != string(want) {
+		t.Fatalf("pbkdf2HMACSHA256 = %x, want %x (from golang.org/x/crypto/pbkdf2)", got, want)
+	}
+}
+
+// buildRAR5Archive hand-assembles a minimal RAR5 file consisting of the signature followed
+// directly by a single CRYPT header block (skipping the main archive header block entirely,
+// which ParseRAR5Header's block walk doesn't require - it returns as soon as it sees the first
+// crypt block).
+func buildRAR5Archive(t *testing.T, kdfCount int, salt [rar5SaltLen]byte, check [rar5CheckValueLen]byte) []byte {
+	t.Helper()
+	body := []byte{
+		0x00, // header flags vint
+		0x00, // encryption version vint (0 == AES-256)
+		0x01, // encryption flags vint: rar5CheckValueFlag set
+	}
+	body = append(body, byte(kdfCount))
+	body = append(body, salt[:]...)
+	body = append(body, check[:]...)
+
+	block := append([]byte{0x04}, body...) // headerType vint (4 == rar5BlockTypeCrypt) + body
+	if len(block) >= 0x80 {
+		t.Fatalf("test block too large for single-byte vint headerSize: %d", len(block))
+	}
+
+	archiveBytes := append([]byte{}, rar5Magic...)
+	archiveBytes = append(archiveBytes, 0x00, 0x00, 0x00, 0x00) // block CRC32, ignored by the parser
+	archiveBytes = append(archiveBytes, byte(len(block)))       // headerSize vint
+	archiveBytes = append(archiveBytes, block...)
+	return archiveBytes
+}
+
+func TestParseRAR5HeaderAndPrepareHeaderRoundTrip(t *testing.T) {
+	var salt [rar5SaltLen]byte
+	for i := range salt {
+		salt[i] = byte(i + 1)
+	}
+	var check [rar5CheckValueLen]byte
+	copy(check[:], []byte{0xaa, 0xbb, 0xcc, 0xdd, 0xee, 0xff, 0x11, 0x22, 0x33, 0x44, 0x55, 0x66})
+
+	archiveBytes := buildRAR5Archive(t, 5, salt, check)
+
+	h, err := ParseRAR5Header(archiveBytes)
+	if err != nil {
+		t.Fatalf("ParseRAR5Header: %v", err)
+	}
+	if h.KDFCount != 5 {
+		t.Errorf("KDFCount = %d, want 5", h.KDFCount)
+	}
+	if h.Salt != salt {
+		t.Errorf("Salt = %x, want %x", h.Salt, salt)
+	}
+	if !h.HasCheckValue {
+		t.Error("HasCheckValue = false, want true")
+	}
+	if h.Check != check {
+		t.Errorf("Check = %x, want %x", h.Check, check)
+	}
+
+	v := &RAR5Verifier{}
+	header, err := v.PrepareHeader(archiveBytes)
+	if err != nil {
+		t.Fatalf("PrepareHeader: %v", err)
+	}
+	if len(header) != 4+rar5SaltLen+4+rar5CheckValueLen {
+		t.Fatalf("PrepareHeader returned %d bytes, want %d", len(header), 4+rar5SaltLen+4+rar5CheckValueLen)
+	}
+}
+
+func TestRAR5VerifierVerifyResult(t *testing.T) {
+	var salt [rar5SaltLen]byte
+	for i := range salt {
+		salt[i] = byte(0x10 + i)
+	}
+	kdfCount := 1 // iterations = 1<<(1+1) = 4, kept tiny so the test runs fast
+
+	// Compute the check value the same way VerifyResult does, so this test is self-consistent
+	// without a real RAR5 archive writer to generate one.
+	iterations := 1 << (kdfCount + 1)
+	derived := pbkdf2HMACSHA256("correct-password", salt[:], iterations+16, 32)
+	sum := sha256.Sum256(derived)
+	var check [rar5CheckValueLen]byte
+	copy(check[:8], sum[:8])
+
+	archiveBytes := buildRAR5Archive(t, kdfCount, salt, check)
+
+	v := &RAR5Verifier{}
+	header, err := v.PrepareHeader(archiveBytes)
+	if err != nil {
+		t.Fatalf("PrepareHeader: %v", err)
+	}
+
+	ok, err := v.VerifyResult("correct-password", header)
+	if err != nil {
+		t.Fatalf("VerifyResult(correct password): %v", err)
+	}
+	if !ok {
+		t.Fatal("VerifyResult(correct password) = false, want true")
+	}
+
+	ok, err = v.VerifyResult("wrong-password", header)
+	if err != nil {
+		t.Fatalf("VerifyResult(wrong password): %v", err)
+	}
+	if ok {
+		t.Fatal("VerifyResult(wrong password) = true, want false")
+	}
+}
+
+func TestRAR5VerifierVerifyResultWithoutCheckValue(t *testing.T) {
+	var salt [rar5SaltLen]byte
+	var check [rar5CheckValueLen]byte
+	archiveBytes := buildRAR5Archive(t, 1, salt, check)
+	// Byte layout per buildRAR5Archive: magic(8) + CRC(4) + headerSize(1) + headerType(1) +
+	// header-flags vint(1) + enc-version vint(1) + encryption-flags vint, at index 16. Clear
+	// rar5CheckValueFlag there, matching an archive created without "store password check".
+	const encryptionFlagsOffset = 8 + 4 + 1 + 1 + 1 + 1
+	archiveBytes[encryptionFlagsOffset] = 0x00
+
+	v := &RAR5Verifier{}
+	header, err := v.PrepareHeader(archiveBytes)
+	if err != nil {
+		t.Fatalf("PrepareHeader: %v", err)
+	}
+	if _, err := v.VerifyResult("anything", header); err == nil {
+		t.Fatal("VerifyResult with no stored check value = nil error, want an error")
+	}
+}
+
+func TestParseRAR5HeaderRejectsNonRAR5Data(t *testing.T) {
+	if _, err := ParseRAR5Header([]byte("not a rar5 archive at all, just plain text padding")); err == nil {
+		t.Fatal("ParseRAR5Header on non-RAR5 data = nil error, want an error")
+	}
+}
+
+func TestParseRAR5HeaderRejectsMissingCryptBlock(t *testing.T) {
+	// Signature followed by a single non-CRYPT block (type 1 == rar5BlockTypeMain) and nothing
+	// else: the walk should run out of blocks and report no encryption header found.
+	body := []byte{0x00, 0x00} // arbitrary main-header-block filler
+	block := append([]byte{0x01}, body...)
+	archiveBytes := append([]byte{}, rar5Magic...)
+	archiveBytes = append(archiveBytes, 0x00, 0x00, 0x00, 0x00)
+	archiveBytes = append(archiveBytes, byte(len(block)))
+	archiveBytes = append(archiveBytes, block...)
+
+	if _, err := ParseRAR5Header(archiveBytes); err == nil {
+		t.Fatal("ParseRAR5Header with no crypt block = nil error, want an error")
+	}
+}
@@ -0,0 +1,206 @@
+package archive
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"testing"
+)
+
+func TestUTF16LE(t *testing.T) {
+	got := utf16LE("Ab1")
+	want := []byte{'A', 0x00, 'b', 0x00, '1', 0x00}
+	if !bytes.Equal(got, want) {
+		t.Fatalf("utf16LE(%q) = %x, want %x", "Ab1", got, want)
+	}
+	if len(utf16LE("")) != 0 {
+		t.Fatalf("utf16LE(\"\") = %x, want empty", utf16LE(""))
+	}
+}
+
+func TestSevenZipDeriveKeySentinelSkipsCycling(t *testing.T) {
+	salt := []byte{0x01, 0x02, 0x03, 0x04}
+	password := "hunter2"
+
+	got := sevenZipDeriveKey(password, salt, 0x3f)
+	sum := sha256.Sum256(append(append([]byte{}, salt...), utf16LE(password)...))
+	if !bytes.Equal(got, sum[:]) {
+		t.Fatalf("sevenZipDeriveKey with numCyclesPower=0x3f = %x, want %x", got, sum[:])
+	}
+}
+
+func TestSevenZipDeriveKeySingleCycleMatchesManualComputation(t *testing.T) {
+	salt := []byte{0xaa, 0xbb}
+	password := "p"
+
+	got := sevenZipDeriveKey(password, salt, 0) // 1<<0 == 1 round
+
+	h := sha256.New()
+	h.Write(salt)
+	h.Write(utf16LE(password))
+	var counter [8]byte // round 0
+	h.Write(counter[:])
+	want := h.Sum(nil)
+
+	if !bytes.Equal(got, want) {
+		t.Fatalf("sevenZipDeriveKey(numCyclesPower=0) = %x, want %x", got, want)
+	}
+}
+
+func TestSevenZipDeriveKeyIsDeterministicAndPasswordSensitive(t *testing.T) {
+	salt := []byte{0x01, 0x02, 0x03, 0x04}
+	a := sevenZipDeriveKey("correct-password", salt, 2)
+	b := sevenZipDeriveKey("correct-password", salt, 2)
+	if !bytes.Equal(a, b) {
+		t.Fatal("sevenZipDeriveKey is not deterministic for identical inputs")
+	}
+	c := sevenZipDeriveKey("wrong-password", salt, 2)
+	if bytes.Equal(a, c) {
+		t.Fatal("sevenZipDeriveKey produced the same key for two different passwords")
+	}
+}
+
+func TestLooksLikeDecodedData(t *testing.T) {
+	lowEntropy := bytes.Repeat([]byte{0x01, 0x02, 0x00, 0x03}, 32) // 0% high-bit-set
+	if !looksLikeDecodedData(lowEntropy) {
+		t.Error("looksLikeDecodedData(low-entropy bytes) = false, want true")
+	}
+
+	highEntropy := make([]byte, 128)
+	for i := range highEntropy {
+		highEntropy[i] = 0xff // 100% high-bit-set
+	}
+	if looksLikeDecodedData(highEntropy) {
+		t.Error("looksLikeDecodedData(all 0xff bytes) = true, want false")
+	}
+
+	if looksLikeDecodedData(nil) {
+		t.Error("looksLikeDecodedData(nil) = true, want false")
+	}
+}
+
+func TestUnpackSevenZipHeaderRoundTripsPrepareHeaderPacking(t *testing.T) {
+	salt := []byte{0x01, 0x02, 0x03, 0x04}
+	iv := bytes.Repeat([]byte{0xee}, sevenZIVMax)
+	ciphertext := bytes.Repeat([]byte{0x42}, 64)
+
+	saltPadded := make([]byte, sevenZSaltMax)
+	copy(saltPadded, salt)
+
+	packed := make([]byte, 0, 4+4+sevenZSaltMax+sevenZIVMax+4+len(ciphertext))
+	packed = appendUint32(packed, 9)
+	packed = appendUint32(packed, uint32(len(salt)))
+	packed = append(packed, saltPadded...)
+	packed = append(packed, iv...)
+	packed = appendUint32(packed, uint32(len(ciphertext)))
+	packed = append(packed, ciphertext...)
+
+	numCyclesPower, gotSalt, gotIV, gotCiphertext, err := unpackSevenZipHeader(packed)
+	if err != nil {
+		t.Fatalf("unpackSevenZipHeader: %v", err)
+	}
+	if numCyclesPower != 9 {
+		t.Errorf("numCyclesPower = %d, want 9", numCyclesPower)
+	}
+	if !bytes.Equal(gotSalt, salt) {
+		t.Errorf("salt = %x, want %x", gotSalt, salt)
+	}
+	if !bytes.Equal(gotIV, iv) {
+		t.Errorf("iv = %x, want %x", gotIV, iv)
+	}
+	if !bytes.Equal(gotCiphertext, ciphertext) {
+		t.Errorf("ciphertext = %x, want %x", gotCiphertext, ciphertext)
+	}
+}
+
+func TestUnpackSevenZipHeaderRejectsTruncatedBuffer(t *testing.T) {
+	if _, _, _, _, err := unpackSevenZipHeader([]byte{0x01, 0x02}); err == nil {
+		t.Fatal("unpackSevenZipHeader on a too-short buffer = nil error, want an error")
+	}
+}
+
+// buildSevenZipArchive hand-assembles a minimal 7z file containing just enough of the signature
+// header and encoded-header coder properties for ParseSevenZipHeader to locate the AES256+SHA256
+// coder and recover its salt/IV/numCyclesPower, mirroring how real 7-Zip archives lay these out
+// under the "-mhe=on" default (see ParseSevenZipHeader's doc comment).
+func buildSevenZipArchive(t *testing.T, numCyclesPower int, salt, iv []byte) []byte {
+	t.Helper()
+	if len(salt) > 0x0f || len(iv) > 0x0f {
+		t.Fatalf("test salt/iv too long to encode in a property nibble: %d/%d", len(salt), len(iv))
+	}
+
+	coderID := []byte{0x06, 0xf1, 0x07, 0x01}
+	firstByte := byte(numCyclesPower) | 0x40 // bit6 set: saltSize/ivSize come from propBytes[1]
+	propBytes := []byte{firstByte, byte(len(iv)<<4) | byte(len(salt))}
+	propBytes = append(propBytes, salt...)
+	propBytes = append(propBytes, iv...)
+
+	props := append(append([]byte{}, coderID...), byte(len(propBytes)))
+	props = append(props, propBytes...)
+
+	nextHeaderOffset := uint64(0)
+	nextHeaderSize := uint64(len(props)) + 16 // pad a little past the coder properties
+
+	header := make([]byte, 32)
+	copy(header[0:6], sevenZMagic)
+	binary.LittleEndian.PutUint64(header[12:20], nextHeaderOffset)
+	binary.LittleEndian.PutUint64(header[20:28], nextHeaderSize)
+
+	archiveBytes := append(header, props...)
+	archiveBytes = append(archiveBytes, bytes.Repeat([]byte{0x00}, int(nextHeaderSize)-len(props))...)
+	// Extra trailing bytes so the ciphertext slice ParseSevenZipHeader captures (starting at the
+	// same packPos as the header, per its own doc comment) has a few blocks to work with.
+	archiveBytes = append(archiveBytes, bytes.Repeat([]byte{0xcd}, 32)...)
+	return archiveBytes
+}
+
+func TestParseSevenZipHeader(t *testing.T) {
+	salt := []byte{0x11, 0x22, 0x33, 0x44}
+	iv := []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08}
+	archiveBytes := buildSevenZipArchive(t, 19, salt, iv)
+
+	h, err := ParseSevenZipHeader(archiveBytes)
+	if err != nil {
+		t.Fatalf("ParseSevenZipHeader: %v", err)
+	}
+	if h.NumCyclesPower != 19 {
+		t.Errorf("NumCyclesPower = %d, want 19", h.NumCyclesPower)
+	}
+	if !bytes.Equal(h.Salt, salt) {
+		t.Errorf("Salt = %x, want %x", h.Salt, salt)
+	}
+	if !bytes.Equal(h.IV[:len(iv)], iv) {
+		t.Errorf("IV = %x, want it to start with %x", h.IV, iv)
+	}
+	if len(h.Ciphertext) == 0 {
+		t.Error("Ciphertext is empty, want at least one AES block")
+	}
+}
+
+func TestParseSevenZipHeaderRejectsNonSevenZipData(t *testing.T) {
+	if _, err := ParseSevenZipHeader([]byte("not a 7z archive, just plain padding bytes here")); err == nil {
+		t.Fatal("ParseSevenZipHeader on non-7z data = nil error, want an error")
+	}
+}
+
+func TestSevenZipVerifierPrepareHeaderAndVerifyResult(t *testing.T) {
+	salt := []byte{0x01, 0x02, 0x03, 0x04}
+	iv := make([]byte, sevenZIVMax)
+	for i := range iv {
+		iv[i] = byte(i)
+	}
+	archiveBytes := buildSevenZipArchive(t, 4, salt, iv[:8])
+
+	v := &SevenZipVerifier{}
+	header, err := v.PrepareHeader(archiveBytes)
+	if err != nil {
+		t.Fatalf("PrepareHeader: %v", err)
+	}
+
+	// VerifyResult can't confirm a real password here since buildSevenZipArchive's trailing bytes
+	// aren't a genuine AES-256-CBC ciphertext; it should still run to completion and return a
+	// plausibility verdict rather than erroring, for any password.
+	if _, err := v.VerifyResult("any-password", header); err != nil {
+		t.Fatalf("VerifyResult: %v", err)
+	}
+}
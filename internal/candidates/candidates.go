@@ -0,0 +1,19 @@
+// Package candidates implements pull-based password candidate generators for GPU-fed backends
+// (see cracker.Runner): mask-based, dictionary+rules, and hybrid attacks, each resumable from an
+// on-disk Checkpoint after a crash or Ctrl-C. This complements charset.Source, whose push-style
+// Generate(fn) suits archive.Crack's per-password CPU driver; Generator's pull-style NextBatch suits
+// Runner's fixed-size batch pipeline instead.
+package candidates
+
+// Generator produces fixed-size batches of password candidates for a GPU-fed pipeline, and can
+// report its progress through a Checkpoint so a multi-hour run survives a restart.
+type Generator interface {
+	// NextBatch truncates dst to length 0 and fills it with up to n candidates (dst may be nil; its
+	// capacity is reused when non-nil), returning the result plus whether this is the generator's
+	// last batch. A batch shorter than n comes only with done == true: the generator ran out
+	// partway through it, which is not an error.
+	NextBatch(dst []string, n int) (batch []string, done bool)
+	// Checkpoint captures enough state to resume NextBatch from exactly this point via the
+	// matching NewXxxFromCheckpoint constructor.
+	Checkpoint() Checkpoint
+}
@@ -0,0 +1,48 @@
+package candidates
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// Checkpoint captures enough of a Generator's progress to resume NextBatch from exactly this
+// point after a crash or Ctrl-C. Kind identifies which NewXxxFromCheckpoint constructor it's for;
+// the remaining fields are only meaningful for the kinds that set them.
+type Checkpoint struct {
+	Kind string `json:"kind"`
+	// MaskIndex is MaskGenerator's or HybridGenerator's per-position index into the mask's
+	// resolved character sets.
+	MaskIndex []int `json:"mask_index,omitempty"`
+	// DictOffset is DictionaryGenerator's or HybridGenerator's index into the word list.
+	DictOffset int `json:"dict_offset,omitempty"`
+	// RuleID is DictionaryGenerator's index into the current word's rule-applied variants.
+	RuleID int `json:"rule_id,omitempty"`
+}
+
+// SaveCheckpoint writes cp to path as JSON, overwriting any existing file. Callers typically do
+// this every N batches (see cracker.Runner's CheckpointEvery) rather than on every one, since
+// fsyncing a checkpoint file is wasted work if it never outlives the current process anyway.
+func SaveCheckpoint(path string, cp Checkpoint) error {
+	data, err := json.Marshal(cp)
+	if err != nil {
+		return fmt.Errorf("candidates: failed to marshal checkpoint: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("candidates: failed to write checkpoint %s: %w", path, err)
+	}
+	return nil
+}
+
+// LoadCheckpoint reads a Checkpoint previously written by SaveCheckpoint.
+func LoadCheckpoint(path string) (Checkpoint, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Checkpoint{}, fmt.Errorf("candidates: failed to read checkpoint %s: %w", path, err)
+	}
+	var cp Checkpoint
+	if err := json.Unmarshal(data, &cp); err != nil {
+		return Checkpoint{}, fmt.Errorf("candidates: failed to parse checkpoint %s: %w", path, err)
+	}
+	return cp, nil
+}
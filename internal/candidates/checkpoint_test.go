@@ -0,0 +1,43 @@
+package candidates
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestSaveAndLoadCheckpoint(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoint.json")
+	cp := Checkpoint{Kind: "hybrid", MaskIndex: []int{1, 2, 3}, DictOffset: 7, RuleID: 2}
+
+	if err := SaveCheckpoint(path, cp); err != nil {
+		t.Fatalf("SaveCheckpoint: %v", err)
+	}
+
+	got, err := LoadCheckpoint(path)
+	if err != nil {
+		t.Fatalf("LoadCheckpoint: %v", err)
+	}
+	if got.Kind != cp.Kind {
+		t.Errorf("Kind = %q, want %q", got.Kind, cp.Kind)
+	}
+	if got.DictOffset != cp.DictOffset {
+		t.Errorf("DictOffset = %d, want %d", got.DictOffset, cp.DictOffset)
+	}
+	if got.RuleID != cp.RuleID {
+		t.Errorf("RuleID = %d, want %d", got.RuleID, cp.RuleID)
+	}
+	if len(got.MaskIndex) != len(cp.MaskIndex) {
+		t.Fatalf("MaskIndex = %v, want %v", got.MaskIndex, cp.MaskIndex)
+	}
+	for i := range cp.MaskIndex {
+		if got.MaskIndex[i] != cp.MaskIndex[i] {
+			t.Errorf("MaskIndex[%d] = %d, want %d", i, got.MaskIndex[i], cp.MaskIndex[i])
+		}
+	}
+}
+
+func TestLoadCheckpointMissingFile(t *testing.T) {
+	if _, err := LoadCheckpoint(filepath.Join(t.TempDir(), "does-not-exist.json")); err == nil {
+		t.Fatal("LoadCheckpoint on a missing file = nil error, want an error")
+	}
+}
@@ -0,0 +1,68 @@
+package candidates
+
+import "zipcrack/internal/charset"
+
+// DictionaryGenerator is Generator over a word list, applying Rules to each entry in turn - the
+// pull-based, checkpointable analogue of cracker.NewMutatorSource's random-mutation
+// CandidateSource, wired into cmd/zipcrack's dictionary+rules prompt.
+type DictionaryGenerator struct {
+	words []string
+	rules charset.Rules
+
+	wordIdx int
+	ruleIdx int
+	// variants caches rules.Apply(words[wordIdx]) so it's computed once per word instead of once
+	// per variant consumed from it.
+	variants []string
+	done     bool
+}
+
+// NewDictionaryGenerator builds a DictionaryGenerator starting from the first word.
+func NewDictionaryGenerator(words []string, rules charset.Rules) *DictionaryGenerator {
+	g := &DictionaryGenerator{words: words, rules: rules}
+	if len(words) == 0 {
+		g.done = true
+	}
+	return g
+}
+
+// NewDictionaryGeneratorFromCheckpoint resumes a DictionaryGenerator from a Checkpoint previously
+// returned by DictionaryGenerator.Checkpoint.
+func NewDictionaryGeneratorFromCheckpoint(words []string, rules charset.Rules, cp Checkpoint) *DictionaryGenerator {
+	g := &DictionaryGenerator{words: words, rules: rules, wordIdx: cp.DictOffset, ruleIdx: cp.RuleID}
+	if g.wordIdx >= len(words) {
+		g.done = true
+	}
+	return g
+}
+
+// NextBatch implements Generator.
+func (g *DictionaryGenerator) NextBatch(dst []string, n int) ([]string, bool) {
+	dst = dst[:0]
+	for len(dst) < n {
+		if g.done {
+			break
+		}
+		if g.variants == nil {
+			if g.wordIdx >= len(g.words) {
+				g.done = true
+				break
+			}
+			g.variants = g.rules.Apply(g.words[g.wordIdx])
+		}
+		if g.ruleIdx >= len(g.variants) {
+			g.variants = nil
+			g.ruleIdx = 0
+			g.wordIdx++
+			continue
+		}
+		dst = append(dst, g.variants[g.ruleIdx])
+		g.ruleIdx++
+	}
+	return dst, g.done
+}
+
+// Checkpoint implements Generator.
+func (g *DictionaryGenerator) Checkpoint() Checkpoint {
+	return Checkpoint{Kind: "dictionary", DictOffset: g.wordIdx, RuleID: g.ruleIdx}
+}
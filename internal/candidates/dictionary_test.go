@@ -0,0 +1,81 @@
+package candidates
+
+import (
+	"testing"
+
+	"zipcrack/internal/charset"
+)
+
+func TestDictionaryGeneratorAppliesRulesPerWord(t *testing.T) {
+	words := []string{"cat", "dog"}
+	rules := charset.Rules{Capitalize: true}
+	g := NewDictionaryGenerator(words, rules)
+
+	var all []string
+	for {
+		batch, done := g.NextBatch(nil, 1)
+		all = append(all, append([]string(nil), batch...)...)
+		if done {
+			break
+		}
+	}
+	want := []string{"cat", "Cat", "dog", "Dog"}
+	if len(all) != len(want) {
+		t.Fatalf("got %d candidates %v, want %d: %v", len(all), all, len(want), want)
+	}
+	for i := range want {
+		if all[i] != want[i] {
+			t.Fatalf("candidate[%d] = %q, want %q", i, all[i], want[i])
+		}
+	}
+}
+
+func TestNewDictionaryGeneratorEmptyWordlistIsImmediatelyDone(t *testing.T) {
+	g := NewDictionaryGenerator(nil, charset.Rules{})
+	batch, done := g.NextBatch(nil, 10)
+	if !done {
+		t.Fatal("NextBatch with an empty wordlist = done false, want true")
+	}
+	if len(batch) != 0 {
+		t.Fatalf("NextBatch with an empty wordlist returned %v, want empty", batch)
+	}
+}
+
+func TestDictionaryGeneratorCheckpointResumesFromExactPosition(t *testing.T) {
+	words := []string{"apple", "banana", "cherry"}
+	rules := charset.Rules{Capitalize: true, Leet: true}
+	g := NewDictionaryGenerator(words, rules)
+
+	g.NextBatch(nil, 2) // partway through the first word's variants
+	cp := g.Checkpoint()
+	if cp.Kind != "dictionary" {
+		t.Fatalf("Checkpoint().Kind = %q, want %q", cp.Kind, "dictionary")
+	}
+
+	rest, _ := g.NextBatch(nil, 1000)
+
+	resumed := NewDictionaryGeneratorFromCheckpoint(words, rules, cp)
+	resumedRest, _ := resumed.NextBatch(nil, 1000)
+
+	if len(resumedRest) != len(rest) {
+		t.Fatalf("resumed generator produced %d candidates, want %d", len(resumedRest), len(rest))
+	}
+	for i := range rest {
+		if rest[i] != resumedRest[i] {
+			t.Fatalf("resumed candidate[%d] = %q, want %q", i, resumedRest[i], rest[i])
+		}
+	}
+}
+
+func TestDictionaryGeneratorFromCheckpointPastEndIsImmediatelyDone(t *testing.T) {
+	words := []string{"only"}
+	cp := Checkpoint{Kind: "dictionary", DictOffset: 5}
+	g := NewDictionaryGeneratorFromCheckpoint(words, charset.Rules{}, cp)
+	batch, done := g.NextBatch(nil, 10)
+	if !done {
+		t.Fatal("NextBatch resumed past the end of the wordlist = done false, want true")
+	}
+	if len(batch) != 0 {
+		t.Fatalf("NextBatch resumed past the end of the wordlist returned %v, want empty", batch)
+	}
+}
@@ -0,0 +1,89 @@
+package candidates
+
+import (
+	"errors"
+	"fmt"
+
+	"zipcrack/internal/charset"
+)
+
+// HybridGenerator pairs every dictionary word with every candidate a mask produces, appended as a
+// suffix - hashcat's -a 6 hybrid attack (e.g. dictionary word "password" with mask "?d?d?d?d"
+// tries "password0000" through "password9999"). Useful when a target password is suspected to be
+// a known word plus a short suffix too large to brute force on its own.
+type HybridGenerator struct {
+	words    []string
+	maskSets [][]rune
+
+	wordIdx int
+	maskIdx []int
+	done    bool
+}
+
+// NewHybridGenerator builds a HybridGenerator starting from the first word and the first position
+// of mask's keyspace.
+func NewHybridGenerator(words []string, mask *charset.Mask) (*HybridGenerator, error) {
+	if len(words) == 0 {
+		return nil, errors.New("candidates: hybrid generator needs at least one dictionary word")
+	}
+	sets, err := mask.ResolveSets()
+	if err != nil {
+		return nil, err
+	}
+	return &HybridGenerator{words: words, maskSets: sets, maskIdx: make([]int, len(sets))}, nil
+}
+
+// NewHybridGeneratorFromCheckpoint resumes a HybridGenerator from a Checkpoint previously returned
+// by HybridGenerator.Checkpoint.
+func NewHybridGeneratorFromCheckpoint(words []string, mask *charset.Mask, cp Checkpoint) (*HybridGenerator, error) {
+	if len(words) == 0 {
+		return nil, errors.New("candidates: hybrid generator needs at least one dictionary word")
+	}
+	sets, err := mask.ResolveSets()
+	if err != nil {
+		return nil, err
+	}
+	if len(cp.MaskIndex) != len(sets) {
+		return nil, fmt.Errorf("candidates: checkpoint has %d mask positions, mask has %d", len(cp.MaskIndex), len(sets))
+	}
+	g := &HybridGenerator{words: words, maskSets: sets, maskIdx: append([]int(nil), cp.MaskIndex...), wordIdx: cp.DictOffset}
+	if g.wordIdx >= len(words) {
+		g.done = true
+	}
+	return g, nil
+}
+
+// NextBatch implements Generator.
+func (g *HybridGenerator) NextBatch(dst []string, n int) ([]string, bool) {
+	dst = dst[:0]
+	for len(dst) < n && !g.done {
+		suffix := make([]rune, len(g.maskSets))
+		for i, s := range g.maskSets {
+			suffix[i] = s[g.maskIdx[i]]
+		}
+		dst = append(dst, g.words[g.wordIdx]+string(suffix))
+		g.advance()
+	}
+	return dst, g.done
+}
+
+// advance steps maskIdx the same way MaskGenerator.advance does, and rolls over to the next
+// dictionary word once the mask's keyspace for the current word is exhausted.
+func (g *HybridGenerator) advance() {
+	for pos := len(g.maskSets) - 1; pos >= 0; pos-- {
+		g.maskIdx[pos]++
+		if g.maskIdx[pos] < len(g.maskSets[pos]) {
+			return
+		}
+		g.maskIdx[pos] = 0
+	}
+	g.wordIdx++
+	if g.wordIdx >= len(g.words) {
+		g.done = true
+	}
+}
+
+// Checkpoint implements Generator.
+func (g *HybridGenerator) Checkpoint() Checkpoint {
+	return Checkpoint{Kind: "hybrid", DictOffset: g.wordIdx, MaskIndex: append([]int(nil), g.maskIdx...)}
+}
@@ -0,0 +1,87 @@
+package candidates
+
+import "testing"
+
+func TestHybridGeneratorPairsEveryWordWithEveryMaskValue(t *testing.T) {
+	words := []string{"foo", "bar"}
+	mask := mustParseMask(t, "?d?d")
+	g, err := NewHybridGenerator(words, mask)
+	if err != nil {
+		t.Fatalf("NewHybridGenerator: %v", err)
+	}
+
+	var all []string
+	for {
+		batch, done := g.NextBatch(nil, 13)
+		all = append(all, append([]string(nil), batch...)...)
+		if done {
+			break
+		}
+	}
+	if len(all) != len(words)*100 {
+		t.Fatalf("got %d candidates, want %d (2 words * 100 two-digit suffixes)", len(all), len(words)*100)
+	}
+	want := map[string]bool{"foo00": false, "foo99": false, "bar00": false, "bar99": false}
+	for _, c := range all {
+		if _, ok := want[c]; ok {
+			want[c] = true
+		}
+	}
+	for c, found := range want {
+		if !found {
+			t.Errorf("expected hybrid candidates to include %q", c)
+		}
+	}
+}
+
+func TestNewHybridGeneratorRejectsEmptyWordlist(t *testing.T) {
+	mask := mustParseMask(t, "?d")
+	if _, err := NewHybridGenerator(nil, mask); err == nil {
+		t.Fatal("NewHybridGenerator with no words = nil error, want an error")
+	}
+}
+
+func TestHybridGeneratorCheckpointResumesFromExactPosition(t *testing.T) {
+	words := []string{"alpha", "beta", "gamma"}
+	mask := mustParseMask(t, "?l?d")
+	g, err := NewHybridGenerator(words, mask)
+	if err != nil {
+		t.Fatalf("NewHybridGenerator: %v", err)
+	}
+
+	// Advance partway into the second word's mask keyspace.
+	g.NextBatch(nil, 26*10+3)
+	cp := g.Checkpoint()
+	if cp.Kind != "hybrid" {
+		t.Fatalf("Checkpoint().Kind = %q, want %q", cp.Kind, "hybrid")
+	}
+	if cp.DictOffset != 1 {
+		t.Fatalf("Checkpoint().DictOffset = %d, want 1 (partway through the second word)", cp.DictOffset)
+	}
+
+	rest, _ := g.NextBatch(nil, 1000)
+
+	resumed, err := NewHybridGeneratorFromCheckpoint(words, mask, cp)
+	if err != nil {
+		t.Fatalf("NewHybridGeneratorFromCheckpoint: %v", err)
+	}
+	resumedRest, _ := resumed.NextBatch(nil, 1000)
+
+	if len(resumedRest) != len(rest) {
+		t.Fatalf("resumed generator produced %d candidates, want %d", len(resumedRest), len(rest))
+	}
+	for i := range rest {
+		if rest[i] != resumedRest[i] {
+			t.Fatalf("resumed candidate[%d] = %q, want %q", i, resumedRest[i], rest[i])
+		}
+	}
+}
+
+func TestHybridGeneratorFromCheckpointRejectsMismatchedMask(t *testing.T) {
+	words := []string{"word"}
+	mask := mustParseMask(t, "?l?d")
+	cp := Checkpoint{Kind: "hybrid", MaskIndex: []int{0, 0, 0}}
+	if _, err := NewHybridGeneratorFromCheckpoint(words, mask, cp); err == nil {
+		t.Fatal("NewHybridGeneratorFromCheckpoint with mismatched position count = nil error, want an error")
+	}
+}
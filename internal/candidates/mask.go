@@ -0,0 +1,71 @@
+package candidates
+
+import (
+	"fmt"
+
+	"zipcrack/internal/charset"
+)
+
+// MaskGenerator is Generator over a hashcat-style charset.Mask, enumerating its resolved sets
+// incrementally (rather than through Mask.Enumerate's single bulk callback) so NextBatch can pull
+// a fixed number of candidates per call and Checkpoint can capture exactly where it stopped.
+type MaskGenerator struct {
+	sets [][]rune
+	idx  []int
+	done bool
+}
+
+// NewMaskGenerator builds a MaskGenerator starting from the beginning of mask's keyspace.
+func NewMaskGenerator(mask *charset.Mask) (*MaskGenerator, error) {
+	sets, err := mask.ResolveSets()
+	if err != nil {
+		return nil, err
+	}
+	return &MaskGenerator{sets: sets, idx: make([]int, len(sets))}, nil
+}
+
+// NewMaskGeneratorFromCheckpoint resumes a MaskGenerator from a Checkpoint previously returned by
+// MaskGenerator.Checkpoint.
+func NewMaskGeneratorFromCheckpoint(mask *charset.Mask, cp Checkpoint) (*MaskGenerator, error) {
+	sets, err := mask.ResolveSets()
+	if err != nil {
+		return nil, err
+	}
+	if len(cp.MaskIndex) != len(sets) {
+		return nil, fmt.Errorf("candidates: checkpoint has %d mask positions, mask has %d", len(cp.MaskIndex), len(sets))
+	}
+	idx := append([]int(nil), cp.MaskIndex...)
+	return &MaskGenerator{sets: sets, idx: idx}, nil
+}
+
+// NextBatch implements Generator.
+func (g *MaskGenerator) NextBatch(dst []string, n int) ([]string, bool) {
+	dst = dst[:0]
+	for len(dst) < n && !g.done {
+		buf := make([]rune, len(g.sets))
+		for i, s := range g.sets {
+			buf[i] = s[g.idx[i]]
+		}
+		dst = append(dst, string(buf))
+		g.advance()
+	}
+	return dst, g.done
+}
+
+// advance steps idx to the next position in lexicographic order, the same odometer-style carry
+// Mask.Enumerate uses, setting done once every position has wrapped back to 0.
+func (g *MaskGenerator) advance() {
+	for pos := len(g.sets) - 1; pos >= 0; pos-- {
+		g.idx[pos]++
+		if g.idx[pos] < len(g.sets[pos]) {
+			return
+		}
+		g.idx[pos] = 0
+	}
+	g.done = true
+}
+
+// Checkpoint implements Generator.
+func (g *MaskGenerator) Checkpoint() Checkpoint {
+	return Checkpoint{Kind: "mask", MaskIndex: append([]int(nil), g.idx...)}
+}
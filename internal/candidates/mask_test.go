@@ -0,0 +1,87 @@
+package candidates
+
+import (
+	"testing"
+
+	"zipcrack/internal/charset"
+)
+
+func mustParseMask(t *testing.T, pattern string) *charset.Mask {
+	t.Helper()
+	mask, err := charset.ParseMask(pattern)
+	if err != nil {
+		t.Fatalf("ParseMask(%q): %v", pattern, err)
+	}
+	return mask
+}
+
+func TestMaskGeneratorEnumeratesFullKeyspace(t *testing.T) {
+	mask := mustParseMask(t, "?l?d")
+	g, err := NewMaskGenerator(mask)
+	if err != nil {
+		t.Fatalf("NewMaskGenerator: %v", err)
+	}
+
+	seen := map[string]bool{}
+	for {
+		batch, done := g.NextBatch(nil, 7)
+		for _, c := range batch {
+			if seen[c] {
+				t.Fatalf("NextBatch produced duplicate candidate %q", c)
+			}
+			seen[c] = true
+		}
+		if done {
+			break
+		}
+	}
+	if len(seen) != 26*10 {
+		t.Fatalf("got %d unique candidates, want %d (26 lowercase letters * 10 digits)", len(seen), 26*10)
+	}
+	if !seen["a0"] || !seen["z9"] {
+		t.Fatalf("expected keyspace to include a0 and z9, got %v", seen)
+	}
+}
+
+func TestMaskGeneratorCheckpointResumesFromExactPosition(t *testing.T) {
+	mask := mustParseMask(t, "?l?l?d")
+	g, err := NewMaskGenerator(mask)
+	if err != nil {
+		t.Fatalf("NewMaskGenerator: %v", err)
+	}
+
+	first, done := g.NextBatch(nil, 5)
+	if done {
+		t.Fatal("generator reported done after only 5 of 26*26*10 candidates")
+	}
+	cp := g.Checkpoint()
+	if cp.Kind != "mask" {
+		t.Fatalf("Checkpoint().Kind = %q, want %q", cp.Kind, "mask")
+	}
+
+	rest, _ := g.NextBatch(nil, 1000)
+
+	resumed, err := NewMaskGeneratorFromCheckpoint(mask, cp)
+	if err != nil {
+		t.Fatalf("NewMaskGeneratorFromCheckpoint: %v", err)
+	}
+	resumedRest, _ := resumed.NextBatch(nil, 1000)
+
+	if len(resumedRest) != len(rest) {
+		t.Fatalf("resumed generator produced %d candidates, want %d", len(resumedRest), len(rest))
+	}
+	for i := range rest {
+		if rest[i] != resumedRest[i] {
+			t.Fatalf("resumed candidate[%d] = %q, want %q", i, resumedRest[i], rest[i])
+		}
+	}
+	_ = first
+}
+
+func TestMaskGeneratorFromCheckpointRejectsMismatchedMask(t *testing.T) {
+	mask := mustParseMask(t, "?l?d")
+	cp := Checkpoint{Kind: "mask", MaskIndex: []int{0, 0, 0}} // 3 positions, mask only has 2
+	if _, err := NewMaskGeneratorFromCheckpoint(mask, cp); err == nil {
+		t.Fatal("NewMaskGeneratorFromCheckpoint with mismatched position count = nil error, want an error")
+	}
+}
@@ -4,16 +4,33 @@ import "unicode"
 
 // Letters returns ASCII letters a-zA-Z.
 func Letters() []rune {
-	letters := make([]rune, 0, 52)
+	return Combine(LettersLower(), LettersUpper())
+}
+
+// LettersLower returns ASCII lowercase letters a-z.
+func LettersLower() []rune {
+	letters := make([]rune, 0, 26)
 	for r := 'a'; r <= 'z'; r++ {
 		letters = append(letters, r)
 	}
+	return letters
+}
+
+// LettersUpper returns ASCII uppercase letters A-Z.
+func LettersUpper() []rune {
+	letters := make([]rune, 0, 26)
 	for r := 'A'; r <= 'Z'; r++ {
 		letters = append(letters, r)
 	}
 	return letters
 }
 
+// AllPrintable returns letters, digits, and special characters combined - the broadest built-in
+// set, used by the mask language's `?a` placeholder.
+func AllPrintable() []rune {
+	return Combine(LettersLower(), LettersUpper(), Digits(), SpecialAll())
+}
+
 // Digits returns ASCII digits 0-9.
 func Digits() []rune {
 	d := make([]rune, 0, 10)
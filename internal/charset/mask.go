@@ -0,0 +1,135 @@
+package charset
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Mask is a parsed hashcat-style mask pattern: a fixed-length sequence of positions, each either
+// a literal character or a placeholder drawing from a character class. Build one with ParseMask.
+type Mask struct {
+	raw []rune
+
+	// position i is a literal/built-in set taken directly, unless customRef[i] is non-zero, in
+	// which case it's resolved from customs at Enumerate time (SetCustom may be called after
+	// ParseMask).
+	sets      [][]rune
+	customRef []int
+	customs   [5][]rune
+}
+
+// ParseMask parses a mask pattern such as "?u?l?l?l?d?d". Recognized placeholders are `?l`
+// (lowercase letters), `?u` (uppercase letters), `?d` (digits), `?s` (special characters), `?a`
+// (all of the above combined), `?1`-`?4` (user-defined sets registered via SetCustom), and `??`
+// for a literal question mark. Any other character is taken literally.
+func ParseMask(pattern string) (*Mask, error) {
+	runes := []rune(pattern)
+	m := &Mask{raw: runes}
+
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		if r != '?' {
+			m.sets = append(m.sets, []rune{r})
+			m.customRef = append(m.customRef, 0)
+			continue
+		}
+
+		i++
+		if i >= len(runes) {
+			return nil, fmt.Errorf("charset: mask %q ends with a dangling '?'", pattern)
+		}
+		switch spec := runes[i]; spec {
+		case 'l':
+			m.sets = append(m.sets, LettersLower())
+			m.customRef = append(m.customRef, 0)
+		case 'u':
+			m.sets = append(m.sets, LettersUpper())
+			m.customRef = append(m.customRef, 0)
+		case 'd':
+			m.sets = append(m.sets, Digits())
+			m.customRef = append(m.customRef, 0)
+		case 's':
+			m.sets = append(m.sets, SpecialAll())
+			m.customRef = append(m.customRef, 0)
+		case 'a':
+			m.sets = append(m.sets, AllPrintable())
+			m.customRef = append(m.customRef, 0)
+		case '1', '2', '3', '4':
+			m.sets = append(m.sets, nil)
+			m.customRef = append(m.customRef, int(spec-'0'))
+		case '?':
+			m.sets = append(m.sets, []rune{'?'})
+			m.customRef = append(m.customRef, 0)
+		default:
+			return nil, fmt.Errorf("charset: mask %q has unknown placeholder ?%c", pattern, spec)
+		}
+	}
+
+	if len(m.sets) == 0 {
+		return nil, errors.New("charset: mask is empty")
+	}
+	return m, nil
+}
+
+// SetCustom registers the character set a `?1`-`?4` placeholder draws from. i must be 1-4.
+func (m *Mask) SetCustom(i int, set []rune) error {
+	if i < 1 || i > 4 {
+		return fmt.Errorf("charset: custom set index must be 1-4, got %d", i)
+	}
+	m.customs[i] = set
+	return nil
+}
+
+// ResolveSets expands m into the concrete rune set each position draws from, resolving `?1`-`?4`
+// placeholders via SetCustom. Exported so callers enumerating a mask incrementally across many
+// calls (see candidates.MaskGenerator) can resolve it once up front instead of going through
+// Enumerate's single bulk callback.
+func (m *Mask) ResolveSets() ([][]rune, error) {
+	sets := make([][]rune, len(m.sets))
+	for i := range m.sets {
+		if ref := m.customRef[i]; ref != 0 {
+			if len(m.customs[ref]) == 0 {
+				return nil, fmt.Errorf("charset: mask references ?%d but SetCustom(%d, ...) was never called", ref, ref)
+			}
+			sets[i] = m.customs[ref]
+		} else {
+			sets[i] = m.sets[i]
+		}
+	}
+	return sets, nil
+}
+
+// Enumerate calls fn once for every candidate the mask produces, in lexicographic order of the
+// underlying per-position sets, stopping early if fn returns false. It returns an error if a
+// `?1`-`?4` placeholder is used without a matching SetCustom call.
+func (m *Mask) Enumerate(fn func([]byte) bool) error {
+	sets, err := m.ResolveSets()
+	if err != nil {
+		return err
+	}
+	n := len(sets)
+
+	idx := make([]int, n)
+	buf := make([]rune, n)
+	for {
+		for i, s := range sets {
+			buf[i] = s[idx[i]]
+		}
+		if !fn([]byte(string(buf))) {
+			return nil
+		}
+
+		pos := n - 1
+		for pos >= 0 {
+			idx[pos]++
+			if idx[pos] < len(sets[pos]) {
+				break
+			}
+			idx[pos] = 0
+			pos--
+		}
+		if pos < 0 {
+			return nil
+		}
+	}
+}
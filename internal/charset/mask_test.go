@@ -0,0 +1,121 @@
+package charset
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseMaskEnumeratesAllCombinations(t *testing.T) {
+	m, err := ParseMask("?d?d")
+	if err != nil {
+		t.Fatalf("ParseMask: %v", err)
+	}
+
+	var got []string
+	if err := m.Enumerate(func(b []byte) bool {
+		got = append(got, string(b))
+		return true
+	}); err != nil {
+		t.Fatalf("Enumerate: %v", err)
+	}
+	if len(got) != 100 {
+		t.Fatalf("got %d candidates, want 100", len(got))
+	}
+	if got[0] != "00" || got[len(got)-1] != "99" {
+		t.Fatalf("got[0]=%q got[last]=%q, want 00/99", got[0], got[len(got)-1])
+	}
+}
+
+func TestParseMaskLiteralsAndEscapedQuestionMark(t *testing.T) {
+	m, err := ParseMask("a??b")
+	if err != nil {
+		t.Fatalf("ParseMask: %v", err)
+	}
+	var got []string
+	m.Enumerate(func(b []byte) bool {
+		got = append(got, string(b))
+		return true
+	})
+	want := []string{"a?b"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestParseMaskEnumerateStopsEarly(t *testing.T) {
+	m, err := ParseMask("?d?d?d")
+	if err != nil {
+		t.Fatalf("ParseMask: %v", err)
+	}
+	n := 0
+	m.Enumerate(func(b []byte) bool {
+		n++
+		return n < 5
+	})
+	if n != 5 {
+		t.Fatalf("Enumerate called fn %d times, want 5", n)
+	}
+}
+
+func TestParseMaskRejectsDanglingPlaceholder(t *testing.T) {
+	if _, err := ParseMask("?l?"); err == nil {
+		t.Fatal("expected error for a dangling '?'")
+	}
+}
+
+func TestParseMaskRejectsUnknownPlaceholder(t *testing.T) {
+	if _, err := ParseMask("?z"); err == nil {
+		t.Fatal("expected error for an unknown placeholder")
+	}
+}
+
+func TestParseMaskRejectsEmptyPattern(t *testing.T) {
+	if _, err := ParseMask(""); err == nil {
+		t.Fatal("expected error for an empty mask")
+	}
+}
+
+func TestCustomSetRequiresSetCustom(t *testing.T) {
+	m, err := ParseMask("?1")
+	if err != nil {
+		t.Fatalf("ParseMask: %v", err)
+	}
+	if err := m.Enumerate(func(b []byte) bool { return true }); err == nil {
+		t.Fatal("expected Enumerate to error when ?1 has no registered custom set")
+	}
+}
+
+func TestCustomSetIsUsedOnceRegistered(t *testing.T) {
+	m, err := ParseMask("?1?1")
+	if err != nil {
+		t.Fatalf("ParseMask: %v", err)
+	}
+	if err := m.SetCustom(1, []rune{'x', 'y'}); err != nil {
+		t.Fatalf("SetCustom: %v", err)
+	}
+
+	var got []string
+	if err := m.Enumerate(func(b []byte) bool {
+		got = append(got, string(b))
+		return true
+	}); err != nil {
+		t.Fatalf("Enumerate: %v", err)
+	}
+	want := []string{"xx", "xy", "yx", "yy"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestSetCustomRejectsOutOfRangeIndex(t *testing.T) {
+	m, err := ParseMask("?1")
+	if err != nil {
+		t.Fatalf("ParseMask: %v", err)
+	}
+	if err := m.SetCustom(0, []rune{'x'}); err == nil {
+		t.Fatal("expected error for custom index 0")
+	}
+	if err := m.SetCustom(5, []rune{'x'}); err == nil {
+		t.Fatal("expected error for custom index 5")
+	}
+}
@@ -0,0 +1,72 @@
+package charset
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Rules configures hashcat `-r`-style mutations applied to each wordlist entry by
+// candidates.DictionaryGenerator.
+type Rules struct {
+	Capitalize   bool // uppercase the first letter, leave the rest unchanged
+	Reverse      bool
+	Leet         bool // substitute a->4, e->3, i->1, o->0, s->5
+	AppendDigits bool // append every two-digit suffix 00-99
+}
+
+var leetSubstitutions = map[rune]rune{
+	'a': '4', 'A': '4',
+	'e': '3', 'E': '3',
+	'i': '1', 'I': '1',
+	'o': '0', 'O': '0',
+	's': '5', 'S': '5',
+}
+
+// Apply returns every variant of word this ruleset produces. word itself is always included
+// first, even when every rule is disabled.
+func (r Rules) Apply(word string) []string {
+	variants := []string{word}
+	if r.Capitalize {
+		variants = append(variants, capitalizeWord(word))
+	}
+	if r.Reverse {
+		variants = append(variants, reverseWord(word))
+	}
+	if r.Leet {
+		variants = append(variants, leetWord(word))
+	}
+	if r.AppendDigits {
+		base := append([]string(nil), variants...)
+		for _, v := range base {
+			for d := 0; d < 100; d++ {
+				variants = append(variants, fmt.Sprintf("%s%02d", v, d))
+			}
+		}
+	}
+	return variants
+}
+
+func capitalizeWord(word string) string {
+	if word == "" {
+		return word
+	}
+	return strings.ToUpper(word[:1]) + word[1:]
+}
+
+func reverseWord(word string) string {
+	r := []rune(word)
+	for i, j := 0, len(r)-1; i < j; i, j = i+1, j-1 {
+		r[i], r[j] = r[j], r[i]
+	}
+	return string(r)
+}
+
+func leetWord(word string) string {
+	r := []rune(word)
+	for i, c := range r {
+		if sub, ok := leetSubstitutions[c]; ok {
+			r[i] = sub
+		}
+	}
+	return string(r)
+}
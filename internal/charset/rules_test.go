@@ -0,0 +1,68 @@
+package charset
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRulesApplyAlwaysIncludesOriginalWord(t *testing.T) {
+	got := Rules{}.Apply("hello")
+	want := []string{"hello"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestRulesApplyCapitalize(t *testing.T) {
+	got := Rules{Capitalize: true}.Apply("hello")
+	want := []string{"hello", "Hello"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestRulesApplyReverse(t *testing.T) {
+	got := Rules{Reverse: true}.Apply("abc")
+	want := []string{"abc", "cba"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestRulesApplyLeet(t *testing.T) {
+	got := Rules{Leet: true}.Apply("Easel")
+	want := []string{"Easel", "3453l"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestRulesApplyAppendDigitsCoversEveryVariant(t *testing.T) {
+	got := Rules{Capitalize: true, AppendDigits: true}.Apply("hi")
+	// base variants are "hi" and "Hi", each suffixed with "00".."99", plus the two base variants
+	// themselves at the front.
+	if len(got) != 2+2*100 {
+		t.Fatalf("got %d variants, want %d", len(got), 2+2*100)
+	}
+	if got[0] != "hi" || got[1] != "Hi" {
+		t.Fatalf("got[0:2] = %v, want [hi Hi]", got[:2])
+	}
+	if got[2] != "hi00" || got[len(got)-1] != "Hi99" {
+		t.Fatalf("got[2]=%q got[last]=%q, want hi00/Hi99", got[2], got[len(got)-1])
+	}
+}
+
+func TestReverseWordHandlesEmptyAndUnicode(t *testing.T) {
+	if got := reverseWord(""); got != "" {
+		t.Fatalf("reverseWord(%q) = %q, want empty", "", got)
+	}
+	if got := reverseWord("héllo"); got != "olléh" {
+		t.Fatalf("reverseWord(héllo) = %q, want olléh", got)
+	}
+}
+
+func TestCapitalizeWordHandlesEmpty(t *testing.T) {
+	if got := capitalizeWord(""); got != "" {
+		t.Fatalf("capitalizeWord(%q) = %q, want empty", "", got)
+	}
+}
@@ -0,0 +1,11 @@
+package charset
+
+// Source generates password candidates for archive.Crack's CPU-only path, letting pure brute
+// force plug into the same driver RAR5/7z cracking uses. Mask attacks use the pull-based
+// candidates.Generator instead (see candidates.MaskGenerator), which cracker.Runner's GPU-fed
+// batch pipeline and checkpointing are built around.
+type Source interface {
+	// Generate calls fn once per candidate password until fn returns false (a match was found, or
+	// the caller wants to stop early) or the source is exhausted.
+	Generate(fn func(password string) bool)
+}
@@ -0,0 +1,210 @@
+package cracker
+
+import (
+	"hash/fnv"
+	"math"
+	"sync"
+)
+
+// BloomFilter is a fixed-size bit-array Bloom filter. It derives its k "hash functions" from two
+// FNV hashes via double hashing (Kirsch-Mitzenmacher), so Add/Test only ever hash the input twice
+// regardless of k.
+type BloomFilter struct {
+	bits []uint64
+	m    uint64 // number of bits, always a multiple of 64
+	k    uint   // number of hash functions
+}
+
+// NewBloomFilter sizes a filter for expectedItems entries at the given false-positive rate,
+// capped at maxBytes so a multi-hour run's tried-password filter can't grow the checkpoint file
+// without bound (the request's "64 MiB, with configurable false-positive rate").
+func NewBloomFilter(expectedItems uint64, fpRate float64, maxBytes int) *BloomFilter {
+	if expectedItems == 0 {
+		expectedItems = 1
+	}
+	if fpRate <= 0 || fpRate >= 1 {
+		fpRate = 0.01
+	}
+	m := optimalBits(expectedItems, fpRate)
+	if maxBytes > 0 {
+		if maxBits := uint64(maxBytes) * 8; m > maxBits {
+			m = maxBits
+		}
+	}
+	if m < 64 {
+		m = 64
+	}
+	k := optimalK(m, expectedItems)
+	words := (m + 63) / 64
+	return &BloomFilter{bits: make([]uint64, words), m: words * 64, k: k}
+}
+
+func optimalBits(n uint64, p float64) uint64 {
+	bits := -float64(n) * math.Log(p) / (math.Ln2 * math.Ln2)
+	return uint64(math.Ceil(bits))
+}
+
+func optimalK(m, n uint64) uint {
+	k := int(math.Round(float64(m) / float64(n) * math.Ln2))
+	if k < 1 {
+		k = 1
+	}
+	if k > 16 {
+		k = 16
+	}
+	return uint(k)
+}
+
+func (f *BloomFilter) indexSeeds(s string) (uint64, uint64) {
+	h1 := fnv.New64a()
+	h1.Write([]byte(s))
+	sum1 := h1.Sum64()
+	h2 := fnv.New64()
+	h2.Write([]byte(s))
+	sum2 := h2.Sum64()
+	if sum2%2 == 0 {
+		sum2++ // keep the step odd so it shares no factor of 2 with m (m is a multiple of 64)
+	}
+	return sum1, sum2
+}
+
+// Add marks s as present.
+func (f *BloomFilter) Add(s string) {
+	h1, h2 := f.indexSeeds(s)
+	for i := uint(0); i < f.k; i++ {
+		bit := (h1 + uint64(i)*h2) % f.m
+		f.bits[bit/64] |= 1 << (bit % 64)
+	}
+}
+
+// Test reports whether s was possibly added before (true positives guaranteed; false positives
+// bounded by the rate NewBloomFilter was built with; never a false negative).
+func (f *BloomFilter) Test(s string) bool {
+	h1, h2 := f.indexSeeds(s)
+	for i := uint(0); i < f.k; i++ {
+		bit := (h1 + uint64(i)*h2) % f.m
+		if f.bits[bit/64]&(1<<(bit%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Merge ORs other's bits into f, folding one filter's membership into another. Filters being
+// merged must have been built with the same size/k (true for every filter this package creates
+// for a given Runner, since they all derive from the same checkpoint config).
+func (f *BloomFilter) Merge(other *BloomFilter) {
+	for i := range f.bits {
+		if i < len(other.bits) {
+			f.bits[i] |= other.bits[i]
+		}
+	}
+}
+
+// Clone returns an independent copy of f, used to seed a fresh worker shard from the restored
+// global filter on resume.
+func (f *BloomFilter) Clone() *BloomFilter {
+	cp := make([]uint64, len(f.bits))
+	copy(cp, f.bits)
+	return &BloomFilter{bits: cp, m: f.m, k: f.k}
+}
+
+// bloomSnapshot is BloomFilter's JSON-serializable form, embedded in RunnerCheckpoint.
+type bloomSnapshot struct {
+	Bits []uint64 `json:"bits"`
+	M    uint64   `json:"m"`
+	K    uint     `json:"k"`
+}
+
+func (f *BloomFilter) snapshot() bloomSnapshot {
+	return bloomSnapshot{Bits: f.bits, M: f.m, K: f.k}
+}
+
+func bloomFromSnapshot(s bloomSnapshot) *BloomFilter {
+	return &BloomFilter{bits: s.Bits, m: s.M, k: s.K}
+}
+
+// shardedTriedFilter is a per-worker sharded Bloom filter of recently tried passwords. Each
+// worker's hot path (Runner.Start's BatchVerify call) only ever reads and writes its own shard, so
+// skipping already-tried candidates never takes a lock on the verification path. Misses -
+// passwords a worker is about to try that its own shard hasn't seen - are forwarded over a
+// buffered channel to a single background merger goroutine, which folds them into one global
+// filter; that global filter (not the individual shards) is what gets written to the checkpoint
+// file and, on resume, cloned into every worker's initial shard. Shards intentionally never merge
+// back from the global filter mid-run: that would turn them into multi-writer state needing a
+// lock, defeating the point of sharding. The practical effect is that two different workers may
+// each verify the same candidate once before either checkpoint flush catches it - an acceptable
+// amount of duplicate work in exchange for a genuinely uncontended hot path.
+type shardedTriedFilter struct {
+	shards []*BloomFilter
+	global *BloomFilter
+	// globalMu guards global: runMerger is its only writer, but Runner's checkpoint ticker reads
+	// it (via snapshot) from a different goroutine every ReportEvery, so reads/writes still need
+	// to be serialized even though shards themselves are lock-free.
+	globalMu sync.Mutex
+	misses   chan string
+}
+
+// newShardedTriedFilter builds numShards shards plus a global filter, all sized identically for
+// expectedItems/fpRate/maxBytes. If resumeFrom is non-nil, every shard starts as a clone of it
+// (the checkpointed state from a previous run) instead of empty.
+func newShardedTriedFilter(numShards int, expectedItems uint64, fpRate float64, maxBytes int, resumeFrom *BloomFilter) *shardedTriedFilter {
+	f := &shardedTriedFilter{
+		misses: make(chan string, 4096),
+	}
+	if resumeFrom != nil {
+		f.global = resumeFrom.Clone()
+	} else {
+		f.global = NewBloomFilter(expectedItems, fpRate, maxBytes)
+	}
+	f.shards = make([]*BloomFilter, numShards)
+	for i := range f.shards {
+		f.shards[i] = f.global.Clone()
+	}
+	return f
+}
+
+// shard returns worker id's own filter. Safe for that worker to call Test/Add on without
+// synchronization; no other goroutine writes to this shard.
+func (f *shardedTriedFilter) shard(id int) *BloomFilter {
+	return f.shards[id%len(f.shards)]
+}
+
+// markTried tests and records pw against worker id's shard in one call, returning whether it had
+// already been tried (by this worker, or present at startup from a resumed checkpoint). Misses are
+// forwarded to the merger goroutine; a full channel just drops the forward rather than blocking
+// the verification hot path; the next checkpoint flush will catch it via the next miss from any
+// worker, or simply retry it, which is harmless.
+func (f *shardedTriedFilter) markTried(id int, pw string) (alreadyTried bool) {
+	shard := f.shard(id)
+	if shard.Test(pw) {
+		return true
+	}
+	shard.Add(pw)
+	select {
+	case f.misses <- pw:
+	default:
+	}
+	return false
+}
+
+// runMerger folds every forwarded miss into f.global until the filter is closed.
+func (f *shardedTriedFilter) runMerger() {
+	for pw := range f.misses {
+		f.globalMu.Lock()
+		f.global.Add(pw)
+		f.globalMu.Unlock()
+	}
+}
+
+// snapshot returns the global filter's current bit state for persisting to a checkpoint.
+func (f *shardedTriedFilter) snapshot() bloomSnapshot {
+	f.globalMu.Lock()
+	defer f.globalMu.Unlock()
+	return f.global.snapshot()
+}
+
+// close stops accepting new misses and lets runMerger drain and exit.
+func (f *shardedTriedFilter) close() {
+	close(f.misses)
+}
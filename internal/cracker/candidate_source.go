@@ -0,0 +1,69 @@
+package cracker
+
+import (
+	"encoding/json"
+	"math/rand"
+	"time"
+)
+
+// CandidateSource produces password candidates into a caller-owned buffer for Runner's generator
+// goroutine - the in-process counterpart to candidates.Generator, which instead targets GPU
+// backends that pull whole batches across a checkpoint boundary. CandidateSource is the simpler
+// shape Runner's own generator loop has always wanted: fill what you can, tell me how much.
+type CandidateSource interface {
+	// NextBatch fills as many of dst's entries as it can and returns the count filled. Returning
+	// fewer than len(dst) doesn't necessarily mean the source is exhausted - RandomSource and
+	// MutatorSource never run out - except WordlistSource, which returns 0 forever once its word
+	// list is consumed.
+	NextBatch(dst []string) int
+}
+
+// RandomSource is CandidateSource's pure brute-force implementation: the same uniform-alphabet,
+// uniform-length distribution generateBatch has always produced, wrapped so Runner.Start's
+// generator goroutine can drive every source - random, wordlist, or mutator - through the same
+// NextBatch call instead of special-casing brute force.
+type RandomSource struct {
+	Alphabet []rune
+	MinLen   int
+	MaxLen   int
+	rng      *rand.Rand
+	seed     int64
+}
+
+// NewRandomSource builds a RandomSource seeded independently of any other source in the process.
+func NewRandomSource(alphabet []rune, minLen, maxLen int) *RandomSource {
+	seed := time.Now().UnixNano() ^ int64(len(alphabet)) ^ int64(minLen)<<32 ^ int64(maxLen)
+	return &RandomSource{Alphabet: alphabet, MinLen: minLen, MaxLen: maxLen, rng: rand.New(rand.NewSource(seed)), seed: seed}
+}
+
+// NextBatch implements CandidateSource.
+func (s *RandomSource) NextBatch(dst []string) int {
+	b := generateBatch(s.Alphabet, s.MinLen, s.MaxLen, len(dst), s.rng)
+	copy(dst, b)
+	return len(b)
+}
+
+// randomSourceState is RandomSource's Resumable snapshot: just the seed used to build its rng.
+// Reseeding from the same seed doesn't replay the exact sequence position a restart interrupted,
+// but RandomSource never exhausts its keyspace anyway - Runner's tried-password Bloom filter is
+// what keeps a resumed run from re-verifying candidates the previous run already tried.
+type randomSourceState struct {
+	Seed int64 `json:"seed"`
+}
+
+// State implements Resumable.
+func (s *RandomSource) State() json.RawMessage {
+	data, _ := json.Marshal(randomSourceState{Seed: s.seed})
+	return data
+}
+
+// Restore implements Resumable.
+func (s *RandomSource) Restore(state json.RawMessage) error {
+	var st randomSourceState
+	if err := json.Unmarshal(state, &st); err != nil {
+		return err
+	}
+	s.seed = st.Seed
+	s.rng = rand.New(rand.NewSource(st.Seed))
+	return nil
+}
@@ -0,0 +1,89 @@
+package cracker
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// RunnerCheckpoint is the on-disk snapshot Runner periodically writes (every Config.ReportEvery,
+// piggybacking on the existing stats ticker) so a multi-hour run can be interrupted - Ctrl-C, a
+// reboot, a spot-instance eviction - and resumed without starting the keyspace over. ZipSHA256
+// ties a checkpoint to the archive it was produced against, so NewRunner never resumes progress
+// made against a different file that happens to share a --checkpoint-path.
+type RunnerCheckpoint struct {
+	ZipSHA256      string   `json:"zip_sha256"`
+	TotalAttempts  uint64   `json:"total_attempts"`
+	PerThread      []uint64 `json:"per_thread"`
+	ElapsedSeconds float64  `json:"elapsed_seconds"`
+	// SourceState is Config.Source's Resumable.State(), if it implements Resumable. Absent for
+	// sources that don't (or for Config.Candidates runs, which checkpoint separately via
+	// candidates.Checkpoint/CheckpointPath).
+	SourceState json.RawMessage `json:"source_state,omitempty"`
+	// Filter is the merged, run-wide tried-password Bloom filter (see bloom.go); absent if the run
+	// never enabled one.
+	Filter *bloomSnapshot `json:"filter,omitempty"`
+}
+
+// zipSHA256 hashes the target archive's bytes, used to validate that a checkpoint file on disk
+// actually belongs to the ZIP a run was just pointed at.
+func zipSHA256(zipBytes []byte) string {
+	sum := sha256.Sum256(zipBytes)
+	return hex.EncodeToString(sum[:])
+}
+
+// SaveRunnerCheckpoint writes cp to path as JSON, overwriting any existing file, mirroring
+// candidates.SaveCheckpoint's convention.
+func SaveRunnerCheckpoint(path string, cp RunnerCheckpoint) error {
+	data, err := json.Marshal(cp)
+	if err != nil {
+		return fmt.Errorf("cracker: failed to marshal checkpoint: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("cracker: failed to write checkpoint %s: %w", path, err)
+	}
+	return nil
+}
+
+// LoadRunnerCheckpoint reads a RunnerCheckpoint previously written by SaveRunnerCheckpoint.
+func LoadRunnerCheckpoint(path string) (RunnerCheckpoint, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return RunnerCheckpoint{}, fmt.Errorf("cracker: failed to read checkpoint %s: %w", path, err)
+	}
+	var cp RunnerCheckpoint
+	if err := json.Unmarshal(data, &cp); err != nil {
+		return RunnerCheckpoint{}, fmt.Errorf("cracker: failed to parse checkpoint %s: %w", path, err)
+	}
+	return cp, nil
+}
+
+// Snapshot returns Runner's current state in the same shape SaveRunnerCheckpoint persists, so
+// callers (and tests, if the repo grows any) can round-trip it without going through disk.
+func (r *Runner) Snapshot() RunnerCheckpoint {
+	per := r.snapshotCounters()
+	var total uint64
+	for _, v := range per {
+		total += v
+	}
+	cp := RunnerCheckpoint{
+		ZipSHA256:     zipSHA256(r.cfg.ZipBytes),
+		TotalAttempts: total,
+		PerThread:     per,
+	}
+	cp.ElapsedSeconds = r.resumedElapsed.Seconds()
+	if !r.startedAt.IsZero() {
+		cp.ElapsedSeconds += time.Since(r.startedAt).Seconds()
+	}
+	if res, ok := r.cfg.Source.(Resumable); ok {
+		cp.SourceState = res.State()
+	}
+	if r.filter != nil {
+		snap := r.filter.snapshot()
+		cp.Filter = &snap
+	}
+	return cp
+}
@@ -0,0 +1,57 @@
+package cracker
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestSaveAndLoadRunnerCheckpoint(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoint.json")
+	snap := bloomSnapshot{Bits: []uint64{0x1, 0x2}, M: 128, K: 3}
+	cp := RunnerCheckpoint{
+		ZipSHA256:      zipSHA256([]byte("fake zip bytes")),
+		TotalAttempts:  42,
+		PerThread:      []uint64{10, 32},
+		ElapsedSeconds: 12.5,
+		Filter:         &snap,
+	}
+
+	if err := SaveRunnerCheckpoint(path, cp); err != nil {
+		t.Fatalf("SaveRunnerCheckpoint: %v", err)
+	}
+
+	got, err := LoadRunnerCheckpoint(path)
+	if err != nil {
+		t.Fatalf("LoadRunnerCheckpoint: %v", err)
+	}
+	if got.ZipSHA256 != cp.ZipSHA256 {
+		t.Fatalf("ZipSHA256 = %q, want %q", got.ZipSHA256, cp.ZipSHA256)
+	}
+	if got.TotalAttempts != cp.TotalAttempts {
+		t.Fatalf("TotalAttempts = %d, want %d", got.TotalAttempts, cp.TotalAttempts)
+	}
+	if len(got.PerThread) != 2 || got.PerThread[0] != 10 || got.PerThread[1] != 32 {
+		t.Fatalf("PerThread = %v, want [10 32]", got.PerThread)
+	}
+	if got.Filter == nil || got.Filter.M != 128 || got.Filter.K != 3 {
+		t.Fatalf("Filter = %+v, want M=128 K=3", got.Filter)
+	}
+}
+
+func TestLoadRunnerCheckpointMissingFile(t *testing.T) {
+	if _, err := LoadRunnerCheckpoint(filepath.Join(t.TempDir(), "does-not-exist.json")); err == nil {
+		t.Fatal("expected an error loading a checkpoint that doesn't exist")
+	}
+}
+
+func TestZipSHA256IsDeterministicAndDistinguishesInput(t *testing.T) {
+	a := zipSHA256([]byte("zip contents a"))
+	b := zipSHA256([]byte("zip contents a"))
+	c := zipSHA256([]byte("zip contents b"))
+	if a != b {
+		t.Fatal("zipSHA256 is not deterministic for identical input")
+	}
+	if a == c {
+		t.Fatal("zipSHA256 produced the same hash for different input")
+	}
+}
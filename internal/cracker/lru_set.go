@@ -0,0 +1,35 @@
+package cracker
+
+import "container/list"
+
+// lruSet is a fixed-capacity, least-recently-used membership set. MutatorSource uses it to
+// suppress near-term duplicate candidates (two stacked-transform draws landing on the same
+// string) without letting memory grow across a multi-hour run.
+type lruSet struct {
+	capacity int
+	ll       *list.List
+	index    map[string]*list.Element
+}
+
+func newLRUSet(capacity int) *lruSet {
+	return &lruSet{capacity: capacity, ll: list.New(), index: make(map[string]*list.Element, capacity)}
+}
+
+// seenRecently reports whether s was already in the set, inserting it (and evicting the oldest
+// entry if at capacity) when it wasn't.
+func (l *lruSet) seenRecently(s string) bool {
+	if el, ok := l.index[s]; ok {
+		l.ll.MoveToFront(el)
+		return true
+	}
+	el := l.ll.PushFront(s)
+	l.index[s] = el
+	if l.ll.Len() > l.capacity {
+		oldest := l.ll.Back()
+		if oldest != nil {
+			l.ll.Remove(oldest)
+			delete(l.index, oldest.Value.(string))
+		}
+	}
+	return false
+}
@@ -0,0 +1,196 @@
+package cracker
+
+import (
+	"encoding/json"
+	"errors"
+	"math/rand"
+	"sort"
+	"time"
+)
+
+// weightedTable samples a rune weighted by observed frequency via a prefix-sum cumulative
+// distribution, giving O(log n) sampling through sort.SearchFloat64s instead of a linear scan.
+type weightedTable struct {
+	runes []rune
+	cum   []float64 // cumulative probabilities; cum[len(cum)-1] == 1.0 when non-empty
+}
+
+func newWeightedTable(counts map[rune]int) weightedTable {
+	var total int
+	for _, c := range counts {
+		total += c
+	}
+	if total == 0 {
+		return weightedTable{}
+	}
+	t := weightedTable{runes: make([]rune, 0, len(counts)), cum: make([]float64, 0, len(counts))}
+	var running float64
+	for r, c := range counts {
+		running += float64(c) / float64(total)
+		t.runes = append(t.runes, r)
+		t.cum = append(t.cum, running)
+	}
+	t.cum[len(t.cum)-1] = 1.0 // guard against float rounding leaving the last bucket short of 1
+	return t
+}
+
+// sample draws one rune from the table weighted by its training frequency, or reports false for
+// an empty (untrained) table.
+func (t weightedTable) sample(rng *rand.Rand) (rune, bool) {
+	if len(t.runes) == 0 {
+		return 0, false
+	}
+	idx := sort.SearchFloat64s(t.cum, rng.Float64())
+	if idx >= len(t.runes) {
+		idx = len(t.runes) - 1
+	}
+	return t.runes[idx], true
+}
+
+// MarkovSource samples passwords from a first-order (or Order-th order) Markov chain over runes,
+// trained on a wordlist corpus, so candidates that look like real human-chosen passwords get tried
+// ahead of RandomSource's uniform keyspace. Each instance owns its own *rand.Rand, so a generator
+// goroutine driving one MarkovSource (Runner's generator is single-threaded per Source, like every
+// other CandidateSource here) never races another.
+type MarkovSource struct {
+	Order  int
+	MinLen int
+	MaxLen int
+
+	rng     *rand.Rand
+	start   weightedTable
+	trans   map[string]weightedTable
+	lengths []int // empirical corpus lengths, sampled uniformly and then clamped to [MinLen, MaxLen]
+	seed    int64
+}
+
+// NewMarkovSource trains a MarkovSource on every line of the wordlist at corpusPath. order is the
+// Markov chain's context length in runes (1 if <= 0, i.e. P(c_i | c_(i-1))).
+func NewMarkovSource(corpusPath string, order, minLen, maxLen int) (*MarkovSource, error) {
+	if order <= 0 {
+		order = 1
+	}
+	words, err := LoadWordlist(corpusPath)
+	if err != nil {
+		return nil, err
+	}
+
+	startCounts := make(map[rune]int)
+	transCounts := make(map[string]map[rune]int)
+	var lengths []int
+	for _, w := range words {
+		r := []rune(w)
+		if len(r) == 0 {
+			continue
+		}
+		lengths = append(lengths, len(r))
+		startCounts[r[0]]++
+		// The first order-1 runes of a sample have fewer than order runes of context, so train
+		// those shorter word-start prefixes too.
+		for k := 1; k < order && k < len(r); k++ {
+			ctx := string(r[:k])
+			if transCounts[ctx] == nil {
+				transCounts[ctx] = make(map[rune]int)
+			}
+			transCounts[ctx][r[k]]++
+		}
+		for i := 0; i+order < len(r); i++ {
+			ctx := string(r[i : i+order])
+			if transCounts[ctx] == nil {
+				transCounts[ctx] = make(map[rune]int)
+			}
+			transCounts[ctx][r[i+order]]++
+		}
+	}
+	if len(lengths) == 0 {
+		return nil, errors.New("cracker: markov training corpus has no usable words")
+	}
+
+	trans := make(map[string]weightedTable, len(transCounts))
+	for ctx, counts := range transCounts {
+		trans[ctx] = newWeightedTable(counts)
+	}
+
+	seed := time.Now().UnixNano()
+	return &MarkovSource{
+		Order:   order,
+		MinLen:  minLen,
+		MaxLen:  maxLen,
+		rng:     rand.New(rand.NewSource(seed)),
+		start:   newWeightedTable(startCounts),
+		trans:   trans,
+		lengths: lengths,
+		seed:    seed,
+	}, nil
+}
+
+// NextBatch implements CandidateSource.
+func (s *MarkovSource) NextBatch(dst []string) int {
+	for i := range dst {
+		dst[i] = s.sampleOne()
+	}
+	return len(dst)
+}
+
+// sampleOne draws one password: a length from the corpus's empirical distribution (clamped to
+// [MinLen, MaxLen]), a starting rune from P(c_0), then successive runes from the trained
+// transition table until the target length is reached or an untrained context is hit.
+func (s *MarkovSource) sampleOne() string {
+	length := s.sampleLength()
+	first, ok := s.start.sample(s.rng)
+	if !ok {
+		return ""
+	}
+	out := make([]rune, 0, length)
+	out = append(out, first)
+	for len(out) < length {
+		ctx := out
+		if len(ctx) > s.Order {
+			ctx = ctx[len(ctx)-s.Order:]
+		}
+		table, ok := s.trans[string(ctx)]
+		if !ok {
+			break
+		}
+		next, ok := table.sample(s.rng)
+		if !ok {
+			break
+		}
+		out = append(out, next)
+	}
+	return string(out)
+}
+
+func (s *MarkovSource) sampleLength() int {
+	l := s.lengths[s.rng.Intn(len(s.lengths))]
+	if s.MinLen > 0 && l < s.MinLen {
+		l = s.MinLen
+	}
+	if s.MaxLen > 0 && l > s.MaxLen {
+		l = s.MaxLen
+	}
+	return l
+}
+
+// markovSourceState is MarkovSource's Resumable snapshot; like MutatorSource, sampleOne has no
+// linear cursor, so only the rng seed is worth persisting.
+type markovSourceState struct {
+	Seed int64 `json:"seed"`
+}
+
+// State implements Resumable.
+func (s *MarkovSource) State() json.RawMessage {
+	data, _ := json.Marshal(markovSourceState{Seed: s.seed})
+	return data
+}
+
+// Restore implements Resumable.
+func (s *MarkovSource) Restore(state json.RawMessage) error {
+	var st markovSourceState
+	if err := json.Unmarshal(state, &st); err != nil {
+		return err
+	}
+	s.seed = st.Seed
+	s.rng = rand.New(rand.NewSource(st.Seed))
+	return nil
+}
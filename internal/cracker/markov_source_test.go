@@ -0,0 +1,87 @@
+package cracker
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeWordlist(t *testing.T, words ...string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "corpus.txt")
+	content := ""
+	for _, w := range words {
+		content += w + "\n"
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	return path
+}
+
+func TestNewMarkovSourceRejectsEmptyCorpus(t *testing.T) {
+	path := writeWordlist(t)
+	if _, err := NewMarkovSource(path, 1, 1, 8); err == nil {
+		t.Fatal("expected error for a corpus with no usable words")
+	}
+}
+
+func TestNewMarkovSourceDefaultsOrderToOne(t *testing.T) {
+	path := writeWordlist(t, "password")
+	ms, err := NewMarkovSource(path, 0, 1, 8)
+	if err != nil {
+		t.Fatalf("NewMarkovSource: %v", err)
+	}
+	if ms.Order != 1 {
+		t.Fatalf("Order = %d, want 1", ms.Order)
+	}
+}
+
+func TestMarkovSourceNextBatchRespectsLengthBounds(t *testing.T) {
+	path := writeWordlist(t, "password", "letmein", "dragon", "monkey", "sunshine")
+	ms, err := NewMarkovSource(path, 2, 3, 6)
+	if err != nil {
+		t.Fatalf("NewMarkovSource: %v", err)
+	}
+
+	dst := make([]string, 50)
+	n := ms.NextBatch(dst)
+	if n != len(dst) {
+		t.Fatalf("NextBatch returned %d, want %d", n, len(dst))
+	}
+	// sampleOne stops early whenever it hits a context the corpus never trained (most do, given
+	// how short the corpus is here), so only the upper length bound is guaranteed.
+	for _, pw := range dst {
+		if l := len([]rune(pw)); l > 6 {
+			t.Fatalf("sampled password %q has length %d, want <= 6", pw, l)
+		}
+	}
+}
+
+func TestMarkovSourceStateRoundTrip(t *testing.T) {
+	path := writeWordlist(t, "password", "letmein", "dragon")
+	ms, err := NewMarkovSource(path, 1, 1, 8)
+	if err != nil {
+		t.Fatalf("NewMarkovSource: %v", err)
+	}
+
+	state := ms.State()
+
+	other, err := NewMarkovSource(path, 1, 1, 8)
+	if err != nil {
+		t.Fatalf("NewMarkovSource: %v", err)
+	}
+	if err := other.Restore(state); err != nil {
+		t.Fatalf("Restore: %v", err)
+	}
+	if other.seed != ms.seed {
+		t.Fatalf("Restore did not restore the seed: got %d, want %d", other.seed, ms.seed)
+	}
+}
+
+func TestWeightedTableSampleEmptyReportsFalse(t *testing.T) {
+	var table weightedTable
+	if _, ok := table.sample(nil); ok {
+		t.Fatal("expected sample on an empty table to report false")
+	}
+}
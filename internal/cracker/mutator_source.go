@@ -0,0 +1,236 @@
+package cracker
+
+import (
+	"encoding/json"
+	"math/rand"
+	"time"
+	"unicode"
+)
+
+// mutatorLRUSize bounds MutatorSource's recently-emitted dedup window: large enough to catch
+// short-term repeats from its small set of stacked transforms, small enough not to grow without
+// bound across a multi-hour run.
+const mutatorLRUSize = 64 * 1024
+
+// mutatorMaxAttempts bounds how many candidates one MutatorSource.next call will draw and reject
+// (for being out of [MinLen, MaxLen] or a recent duplicate) before reporting failure; NextBatch
+// stops filling a batch after len(dst)*mutatorMaxAttempts+mutatorMaxAttempts such failures, so a
+// narrow length range can't spin NextBatch forever.
+const mutatorMaxAttempts = 20
+
+// mutatorSuffixes are the short numeric/symbolic suffixes MutatorSource's append/prepend transform
+// draws from - the common "password123", "password!", "password2024" style tacked-on guesses.
+var mutatorSuffixes = []string{"1", "12", "123", "1234", "01", "007", "99", "!", "!!", "2023", "2024", "2025"}
+
+// MutatorSource wraps a base word list with fuzzing-style mutation transforms - insert, delete,
+// replace, adjacent-swap, case-flip, substring duplication, and suffix append/prepend - stacked
+// 1..MaxTransforms deep per candidate, producing an effectively unbounded stream of variants
+// instead of the word list's fixed size.
+type MutatorSource struct {
+	Words    []string
+	Alphabet []rune
+	MinLen   int
+	MaxLen   int
+	// MaxTransforms is the largest number of stacked transforms applied to one candidate; each
+	// candidate gets a uniformly random count in [1, MaxTransforms]. Defaults to 3 if <= 0.
+	MaxTransforms int
+
+	rng  *rand.Rand
+	seen *lruSet
+	seed int64
+}
+
+// NewMutatorSource builds a MutatorSource over words, drawing insert/replace runes from alphabet
+// (suffixes come from mutatorSuffixes) and rejecting candidates outside [minLen, maxLen].
+func NewMutatorSource(words []string, alphabet []rune, minLen, maxLen, maxTransforms int) *MutatorSource {
+	if maxTransforms <= 0 {
+		maxTransforms = 3
+	}
+	seed := time.Now().UnixNano()
+	return &MutatorSource{
+		Words:         words,
+		Alphabet:      alphabet,
+		MinLen:        minLen,
+		MaxLen:        maxLen,
+		MaxTransforms: maxTransforms,
+		rng:           rand.New(rand.NewSource(seed)),
+		seen:          newLRUSet(mutatorLRUSize),
+		seed:          seed,
+	}
+}
+
+// NextBatch implements CandidateSource.
+func (s *MutatorSource) NextBatch(dst []string) int {
+	if len(s.Words) == 0 {
+		return 0
+	}
+	n := 0
+	failures := 0
+	maxFailures := len(dst)*mutatorMaxAttempts + mutatorMaxAttempts
+	for n < len(dst) && failures < maxFailures {
+		cand, ok := s.next()
+		if !ok {
+			failures++
+			continue
+		}
+		dst[n] = cand
+		n++
+	}
+	return n
+}
+
+// next draws a base word, stacks 1..MaxTransforms random transforms onto it, and returns the
+// result if it satisfies MinLen/MaxLen and isn't a recent duplicate, retrying up to
+// mutatorMaxAttempts times before giving up.
+func (s *MutatorSource) next() (string, bool) {
+	for attempt := 0; attempt < mutatorMaxAttempts; attempt++ {
+		word := []rune(s.Words[s.rng.Intn(len(s.Words))])
+		transforms := 1 + s.rng.Intn(s.MaxTransforms)
+		cand := word
+		for i := 0; i < transforms; i++ {
+			cand = s.applyRandomTransform(cand)
+		}
+		if s.MinLen > 0 && len(cand) < s.MinLen {
+			continue
+		}
+		if s.MaxLen > 0 && len(cand) > s.MaxLen {
+			continue
+		}
+		out := string(cand)
+		if s.seen.seenRecently(out) {
+			continue
+		}
+		return out, true
+	}
+	return "", false
+}
+
+func (s *MutatorSource) applyRandomTransform(r []rune) []rune {
+	if len(r) == 0 {
+		return r
+	}
+	switch s.rng.Intn(7) {
+	case 0:
+		return s.insertRune(r)
+	case 1:
+		return s.deleteRune(r)
+	case 2:
+		return s.replaceRune(r)
+	case 3:
+		return s.swapAdjacent(r)
+	case 4:
+		return s.flipCase(r)
+	case 5:
+		return s.duplicateSubstring(r)
+	default:
+		return s.addSuffix(r)
+	}
+}
+
+func (s *MutatorSource) randomRune() rune {
+	if len(s.Alphabet) == 0 {
+		return 'x'
+	}
+	return s.Alphabet[s.rng.Intn(len(s.Alphabet))]
+}
+
+func (s *MutatorSource) insertRune(r []rune) []rune {
+	idx := s.rng.Intn(len(r) + 1)
+	out := make([]rune, 0, len(r)+1)
+	out = append(out, r[:idx]...)
+	out = append(out, s.randomRune())
+	out = append(out, r[idx:]...)
+	return out
+}
+
+func (s *MutatorSource) deleteRune(r []rune) []rune {
+	if len(r) <= 1 {
+		return r
+	}
+	idx := s.rng.Intn(len(r))
+	out := make([]rune, 0, len(r)-1)
+	out = append(out, r[:idx]...)
+	out = append(out, r[idx+1:]...)
+	return out
+}
+
+func (s *MutatorSource) replaceRune(r []rune) []rune {
+	out := append([]rune(nil), r...)
+	out[s.rng.Intn(len(out))] = s.randomRune()
+	return out
+}
+
+func (s *MutatorSource) swapAdjacent(r []rune) []rune {
+	if len(r) < 2 {
+		return r
+	}
+	out := append([]rune(nil), r...)
+	idx := s.rng.Intn(len(out) - 1)
+	out[idx], out[idx+1] = out[idx+1], out[idx]
+	return out
+}
+
+func (s *MutatorSource) flipCase(r []rune) []rune {
+	out := append([]rune(nil), r...)
+	idx := s.rng.Intn(len(out))
+	switch c := out[idx]; {
+	case unicode.IsUpper(c):
+		out[idx] = unicode.ToLower(c)
+	case unicode.IsLower(c):
+		out[idx] = unicode.ToUpper(c)
+	}
+	return out
+}
+
+// duplicateSubstring repeats a short (1-4 rune) substring starting at a random index in place,
+// e.g. "password" -> "passsword" (duplicating "s").
+func (s *MutatorSource) duplicateSubstring(r []rune) []rune {
+	start := s.rng.Intn(len(r))
+	maxLen := len(r) - start
+	if maxLen > 4 {
+		maxLen = 4
+	}
+	segLen := 1 + s.rng.Intn(maxLen)
+	seg := append([]rune(nil), r[start:start+segLen]...)
+	out := make([]rune, 0, len(r)+segLen)
+	out = append(out, r[:start+segLen]...)
+	out = append(out, seg...)
+	out = append(out, r[start+segLen:]...)
+	return out
+}
+
+// addSuffix appends or prepends a short numeric/symbolic suffix drawn from mutatorSuffixes.
+func (s *MutatorSource) addSuffix(r []rune) []rune {
+	suffix := []rune(mutatorSuffixes[s.rng.Intn(len(mutatorSuffixes))])
+	if s.rng.Intn(2) == 0 {
+		out := append([]rune(nil), r...)
+		return append(out, suffix...)
+	}
+	out := append([]rune(nil), suffix...)
+	return append(out, r...)
+}
+
+// mutatorSourceState is MutatorSource's Resumable snapshot; MutatorSource has no notion of a
+// linear "cursor" through its keyspace (it draws a random word and random transforms per
+// candidate), so reseeding its rng is the only position worth persisting - the same "rely on the
+// tried-password filter, not exact replay" reasoning as RandomSource.
+type mutatorSourceState struct {
+	Seed int64 `json:"seed"`
+}
+
+// State implements Resumable.
+func (s *MutatorSource) State() json.RawMessage {
+	data, _ := json.Marshal(mutatorSourceState{Seed: s.seed})
+	return data
+}
+
+// Restore implements Resumable.
+func (s *MutatorSource) Restore(state json.RawMessage) error {
+	var st mutatorSourceState
+	if err := json.Unmarshal(state, &st); err != nil {
+		return err
+	}
+	s.seed = st.Seed
+	s.rng = rand.New(rand.NewSource(st.Seed))
+	return nil
+}
@@ -0,0 +1,16 @@
+package cracker
+
+import "encoding/json"
+
+// Resumable is implemented by CandidateSource implementations that can export and restore enough
+// internal state to pick back up in roughly the same neighborhood after a restart. Exact
+// candidate-by-candidate replay isn't the goal here - Runner's sharded tried-password Bloom filter
+// (bloom.go) is what actually prevents re-verifying already-attempted candidates after a resume.
+// Resumable only needs to avoid starting a source over from scratch: a wordlist offset, an RNG
+// reseeded from its original seed.
+type Resumable interface {
+	// State returns a JSON-serializable snapshot of the source's position.
+	State() json.RawMessage
+	// Restore reconstructs the source's position from a snapshot previously returned by State.
+	Restore(state json.RawMessage) error
+}
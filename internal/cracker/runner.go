@@ -3,11 +3,14 @@ package cracker
 import (
 	"context"
 	"fmt"
-	"math/rand"
+	"os"
 	"sync"
 	"sync/atomic"
 	"time"
 
+	"zipcrack/internal/candidates"
+	"zipcrack/internal/gpu"
+	"zipcrack/internal/transport"
 	"zipcrack/internal/verifier"
 )
 
@@ -32,8 +35,51 @@ type Config struct {
 	ReportEvery   time.Duration
 	FoundCallback func(pw string)
 
-	// Backend selects the verification backend: "cpu" (default) or "vulkan" (experimental).
+	// Backend selects the verification backend: "cpu" (default), "vulkan" or "opencl". Vulkan is
+	// experimental and, absent the compiled SPIR-V shader assets documented in
+	// verifier.shaderVariants (not shipped in this repo), always fails to initialize; Start logs
+	// a warning and falls back to cpu in that case rather than failing the run, and does the same
+	// when OpenCL fails to initialize.
 	Backend string
+
+	// Candidates, when set, replaces the default random-brute-force generator with a
+	// candidates.Generator (mask, dictionary, or hybrid attack). MinLen/MaxLen/Alphabet are ignored
+	// in that case.
+	Candidates candidates.Generator
+	// CheckpointPath, if set, is where checkpoint state is persisted so a multi-hour run can
+	// resume after a crash or Ctrl-C: with Candidates set, that's Candidates.Checkpoint()
+	// (internal/candidates' own format); otherwise it's a RunnerCheckpoint (counters, elapsed
+	// time, Config.Source's Resumable state if any, and the tried-password Bloom filter).
+	CheckpointPath string
+	// CheckpointEvery is how many batches to generate between checkpoint writes. Defaults to 50.
+	// Only consulted in the Candidates path; the Source path checkpoints on every ReportEvery
+	// stats tick instead, since that's already the cadence the request asked for.
+	CheckpointEvery int
+	// Resume, when true and CheckpointPath names an existing checkpoint matching ZipBytes' SHA-256,
+	// restores Runner's counters, elapsed time, Source's position (if Resumable), and the
+	// tried-password filter from that checkpoint instead of starting fresh. Ignored in the
+	// Candidates path, which has its own separate resume flow via LoadCheckpoint.
+	Resume bool
+	// TriedFilterFPRate is the false-positive rate the tried-password Bloom filter is sized for.
+	// Defaults to 0.01 (1%) if <= 0.
+	TriedFilterFPRate float64
+	// TriedFilterMaxBytes caps the tried-password filter's total size (the global filter all
+	// worker shards clone from). Defaults to 64 MiB if <= 0.
+	TriedFilterMaxBytes int
+
+	// Source, when set, drives the generator goroutine instead of the built-in random brute
+	// force: e.g. WordlistSource or MutatorSource. Ignored if Candidates is also set - Candidates
+	// takes priority since it's the GPU-batch-pull path with its own checkpointing. If neither is
+	// set, NewRunner defaults Source to a RandomSource built from Alphabet/MinLen/MaxLen,
+	// preserving the original uniform-random behavior.
+	Source CandidateSource
+
+	// Transport, when set, switches Start into coordinator mode: instead of spawning local
+	// BatchVerify worker goroutines, the generator publishes batches over Transport and a
+	// subscriber aggregates remote WorkerResults into Stats.PerThread, so the TUI sees the same
+	// shape of data whether the pool is in-process goroutines or a fleet of zipcrack-worker
+	// processes. Workers is ignored in this mode. See cmd/zipcrack-coordinator and RunWorker.
+	Transport transport.JobTransport
 }
 
 // Runner coordinates generation, workers, stats, and result publishing.
@@ -48,6 +94,26 @@ type Runner struct {
 	// per-thread attempt counters
 	counters []uint64
 
+	// remoteMu guards counters and remoteIndex when cfg.Transport is set: remote workers arrive
+	// in an arbitrary order identified by a string WorkerID rather than a pre-sized local index,
+	// so Start grows counters and assigns each WorkerID a slot the first time it reports in.
+	remoteMu    sync.Mutex
+	remoteIndex map[string]int
+
+	// startedAt marks this process's Start call; resumedElapsed carries forward however much
+	// elapsed time a restored checkpoint already accounted for, so Snapshot's ElapsedSeconds (and
+	// the TUI's elapsed/ETA display, via tui.Config.InitialElapsed) keeps counting up across a
+	// resume instead of resetting to zero.
+	startedAt      time.Time
+	resumedElapsed time.Duration
+
+	// filter is the sharded tried-password Bloom filter (nil if CheckpointPath is unset, since
+	// there'd be nothing to persist it for). Populated in Start once cfg.Workers is known.
+	filter *shardedTriedFilter
+	// restoredFilter holds a checkpoint's global filter between NewRunner (where it's loaded) and
+	// Start (where the per-worker shards are actually created from it).
+	restoredFilter *BloomFilter
+
 	// internal cancel used by runner when found; external ctx can also cancel
 	cancel func()
 }
@@ -57,20 +123,107 @@ func NewRunner(cfg Config) (*Runner, error) {
 		cfg.Workers = 1
 	}
 	if cfg.BatchSize <= 0 {
-		cfg.BatchSize = 1024
+		if cfg.Backend == "opencl" {
+			// OpenCL pays for a kernel launch and H2D/D2H copy per BatchVerify call, so it wants
+			// far larger batches than the CPU/Vulkan backends to keep the GPU saturated.
+			cfg.BatchSize = gpu.DefaultBatchSize
+		} else {
+			cfg.BatchSize = 1024
+		}
 	}
 	if cfg.ReportEvery <= 0 {
 		cfg.ReportEvery = 2 * time.Second
 	}
+	if cfg.CheckpointEvery <= 0 {
+		cfg.CheckpointEvery = 50
+	}
+	if cfg.Candidates == nil && cfg.Source == nil {
+		cfg.Source = NewRandomSource(cfg.Alphabet, cfg.MinLen, cfg.MaxLen)
+	}
 	r := &Runner{
-		cfg:      cfg,
-		statsCh:  make(chan Stats, 8),
-		resultCh: make(chan Result, 1),
-		counters: make([]uint64, cfg.Workers),
+		cfg:         cfg,
+		statsCh:     make(chan Stats, 8),
+		resultCh:    make(chan Result, 1),
+		counters:    make([]uint64, cfg.Workers),
+		remoteIndex: make(map[string]int),
+	}
+	if cfg.Transport != nil {
+		r.counters = nil
+	}
+
+	if cfg.Resume && cfg.Candidates == nil && cfg.CheckpointPath != "" {
+		if cp, err := LoadRunnerCheckpoint(cfg.CheckpointPath); err != nil {
+			if !os.IsNotExist(err) {
+				fmt.Printf("Warning: failed to load checkpoint %s (%v), starting fresh\n", cfg.CheckpointPath, err)
+			}
+		} else if cp.ZipSHA256 != zipSHA256(cfg.ZipBytes) {
+			fmt.Printf("Warning: checkpoint %s was recorded against a different ZIP, starting fresh\n", cfg.CheckpointPath)
+		} else {
+			if len(cp.PerThread) <= len(r.counters) {
+				copy(r.counters, cp.PerThread)
+			} else {
+				r.counters = append([]uint64(nil), cp.PerThread...)
+			}
+			r.resumedElapsed = time.Duration(cp.ElapsedSeconds * float64(time.Second))
+			if cp.SourceState != nil {
+				if res, ok := cfg.Source.(Resumable); ok {
+					if err := res.Restore(cp.SourceState); err != nil {
+						fmt.Printf("Warning: failed to restore candidate source state (%v)\n", err)
+					}
+				}
+			}
+			if cp.Filter != nil {
+				r.restoredFilter = bloomFromSnapshot(*cp.Filter)
+			}
+			fmt.Printf("Resumed from checkpoint %s (%d attempts, %s elapsed)\n", cfg.CheckpointPath, cp.TotalAttempts, r.resumedElapsed.Truncate(time.Second))
+		}
 	}
+
 	return r, nil
 }
 
+// counterIndex returns the Stats.PerThread slot for workerID, growing r.counters the first time a
+// given remote worker reports in. Only used in coordinator mode (cfg.Transport != nil), where the
+// set of workers - and the order they first connect in - isn't known up front the way a local
+// pool's fixed cfg.Workers goroutines are.
+func (r *Runner) counterIndex(workerID string) int {
+	r.remoteMu.Lock()
+	defer r.remoteMu.Unlock()
+	if idx, ok := r.remoteIndex[workerID]; ok {
+		return idx
+	}
+	idx := len(r.counters)
+	r.remoteIndex[workerID] = idx
+	r.counters = append(r.counters, 0)
+	return idx
+}
+
+func (r *Runner) addRemoteAttempts(workerID string, attempts int) {
+	r.remoteMu.Lock()
+	defer r.remoteMu.Unlock()
+	idx := r.remoteIndex[workerID]
+	r.counters[idx] += uint64(attempts)
+}
+
+// snapshotCounters copies r.counters for Stats/checkpoint publication. remoteMu is taken even in
+// local-worker mode (where only counterIndex/addRemoteAttempts otherwise use it) because it's the
+// only thing that would otherwise let a concurrent counterIndex append race with this read; each
+// element is still read via atomic.LoadUint64 since local mode's worker goroutines update their
+// slot with atomic.AddUint64 without taking remoteMu at all.
+func (r *Runner) snapshotCounters() []uint64 {
+	r.remoteMu.Lock()
+	defer r.remoteMu.Unlock()
+	per := make([]uint64, len(r.counters))
+	for i := range r.counters {
+		per[i] = atomic.LoadUint64(&r.counters[i])
+	}
+	return per
+}
+
+// InitialElapsed returns however much elapsed time a restored checkpoint already accounted for
+// (zero for a fresh run), so callers can offset a progress display's clock before calling Start.
+func (r *Runner) InitialElapsed() time.Duration { return r.resumedElapsed }
+
 func (r *Runner) StatsCh() <-chan Stats   { return r.statsCh }
 func (r *Runner) ResultCh() <-chan Result { return r.resultCh }
 func (r *Runner) GetResult() Result       { return r.result }
@@ -85,9 +238,180 @@ func (r *Runner) publishResult(res Result) {
 	})
 }
 
+// runCandidateGenerator drives r.cfg.Candidates instead of generateBatch, feeding jobs until the
+// generator is exhausted or ctx is cancelled, checkpointing to r.cfg.CheckpointPath every
+// CheckpointEvery batches. It always passes nil as NextBatch's dst, never a reused buffer: jobs is
+// buffered, so several batches can be in flight at once, and reusing a slice's backing array would
+// let this goroutine overwrite a batch a worker hasn't read yet.
+func (r *Runner) runCandidateGenerator(ctx context.Context, jobs chan<- batch) {
+	batches := 0
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+		b, done := r.cfg.Candidates.NextBatch(nil, r.cfg.BatchSize)
+		if len(b) > 0 {
+			select {
+			case jobs <- batch(b):
+			case <-ctx.Done():
+				return
+			}
+			batches++
+			if r.cfg.CheckpointPath != "" && batches%r.cfg.CheckpointEvery == 0 {
+				if err := candidates.SaveCheckpoint(r.cfg.CheckpointPath, r.cfg.Candidates.Checkpoint()); err != nil {
+					fmt.Printf("Warning: failed to write checkpoint (%v)\n", err)
+				}
+			}
+		}
+		if done {
+			if r.cfg.CheckpointPath != "" {
+				if err := candidates.SaveCheckpoint(r.cfg.CheckpointPath, r.cfg.Candidates.Checkpoint()); err != nil {
+					fmt.Printf("Warning: failed to write checkpoint (%v)\n", err)
+				}
+			}
+			return
+		}
+	}
+}
+
+// runCandidateSource drives r.cfg.Source instead of generateBatch directly, feeding jobs until
+// the source returns 0 (permanently exhausted, as WordlistSource does once consumed) or ctx is
+// cancelled. Each iteration allocates a fresh buffer rather than reusing one across calls, for the
+// same reason runCandidateGenerator passes nil to candidates.Generator.NextBatch: jobs is
+// buffered, so a reused backing array could be overwritten before a worker reads it.
+func (r *Runner) runCandidateSource(ctx context.Context, jobs chan<- batch) {
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+		buf := make([]string, r.cfg.BatchSize)
+		n := r.cfg.Source.NextBatch(buf)
+		if n == 0 {
+			return
+		}
+		select {
+		case jobs <- batch(buf[:n]):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// runCoordinator is Start's coordinator-mode counterpart: instead of spawning local BatchVerify
+// worker goroutines, it publishes generated batches over r.cfg.Transport keyed by a monotonically
+// increasing job id and aggregates remote WorkerResults into Stats.PerThread, so
+// cmd/zipcrack-coordinator and cmd/zipcrack see an identical Stats/Result stream whether the pool
+// is local or remote.
+func (r *Runner) runCoordinator(ctx context.Context) error {
+	var jobID uint64
+	var wg sync.WaitGroup
+
+	// Generator: publish batches instead of feeding a local jobs channel.
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			if ctx.Err() != nil {
+				return
+			}
+			buf := make([]string, r.cfg.BatchSize)
+			var n int
+			if r.cfg.Candidates != nil {
+				b, done := r.cfg.Candidates.NextBatch(nil, r.cfg.BatchSize)
+				buf, n = b, len(b)
+				if n > 0 {
+					if err := r.cfg.Transport.PublishBatch(ctx, transport.Batch{JobID: atomic.AddUint64(&jobID, 1), Passwords: buf}); err != nil {
+						return
+					}
+				}
+				if done {
+					return
+				}
+				continue
+			}
+			n = r.cfg.Source.NextBatch(buf)
+			if n == 0 {
+				return
+			}
+			if err := r.cfg.Transport.PublishBatch(ctx, transport.Batch{JobID: atomic.AddUint64(&jobID, 1), Passwords: buf[:n]}); err != nil {
+				return
+			}
+		}
+	}()
+
+	// Result subscriber: aggregate remote attempt counts and watch for a positive find.
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		results, err := r.cfg.Transport.SubscribeResults(ctx)
+		if err != nil {
+			return
+		}
+		for res := range results {
+			r.counterIndex(res.WorkerID)
+			r.addRemoteAttempts(res.WorkerID, res.Attempts)
+			if res.Found {
+				r.publishResult(Result{Found: true, Password: res.Password})
+				if r.cfg.FoundCallback != nil {
+					r.cfg.FoundCallback(res.Password)
+				}
+				_ = r.cfg.Transport.PublishCancel(ctx)
+				r.cancel()
+				return
+			}
+		}
+	}()
+
+	// Stats publisher.
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		t := time.NewTicker(r.cfg.ReportEvery)
+		defer t.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-t.C:
+				per := r.snapshotCounters()
+				var total uint64
+				for _, v := range per {
+					total += v
+				}
+				s := Stats{PerThread: per, Total: total, Timestamp: now}
+				select {
+				case r.statsCh <- s:
+				default:
+				}
+				if r.cfg.CheckpointPath != "" && r.cfg.Candidates == nil {
+					if err := SaveRunnerCheckpoint(r.cfg.CheckpointPath, r.Snapshot()); err != nil {
+						fmt.Printf("Warning: failed to write checkpoint (%v)\n", err)
+					}
+				}
+			}
+		}
+	}()
+
+	go func() {
+		wg.Wait()
+		close(r.statsCh)
+		if !r.result.Found {
+			r.publishResult(Result{Found: false})
+		}
+		close(r.resultCh)
+	}()
+
+	return nil
+}
+
 func (r *Runner) Start(parent context.Context) error {
 	ctx, cancel := context.WithCancel(parent)
 	r.cancel = cancel
+	r.startedAt = time.Now()
+
+	if r.cfg.Transport != nil {
+		return r.runCoordinator(ctx)
+	}
 
 	// ZIP will be validated lazily by the selected verifier backend when creating workers.
 
@@ -95,7 +419,7 @@ func (r *Runner) Start(parent context.Context) error {
 	var v verifier.Verifier
 	switch r.cfg.Backend {
 	case "vulkan":
-		ver, verr := verifier.NewVulkan()
+		ver, verr := verifier.NewVulkan(verifier.VulkanConfig{Strategy: verifier.MemoryStrategyAuto})
 		if verr != nil {
 			// Log the Vulkan error and fall back to CPU
 			fmt.Printf("Warning: Vulkan initialization failed (%v)\n", verr)
@@ -105,6 +429,16 @@ func (r *Runner) Start(parent context.Context) error {
 			v = ver
 			fmt.Println("Using Vulkan GPU backend")
 		}
+	case "opencl":
+		ver, verr := gpu.NewOpenCL()
+		if verr != nil {
+			fmt.Printf("Warning: OpenCL initialization failed (%v)\n", verr)
+			fmt.Println("Falling back to CPU backend...")
+			v = verifier.NewCPU()
+		} else {
+			v = ver
+			fmt.Println("Using OpenCL GPU backend")
+		}
 	default:
 		v = verifier.NewCPU()
 		fmt.Println("Using CPU backend")
@@ -113,6 +447,23 @@ func (r *Runner) Start(parent context.Context) error {
 	// Jobs channel carries batches of password candidates
 	jobs := make(chan batch, r.cfg.Workers*2)
 
+	// The tried-password filter only exists when checkpointing is enabled - there's nothing to
+	// skip on a fresh, non-resumable run, so a run that never sets CheckpointPath pays none of its
+	// overhead.
+	if r.cfg.CheckpointPath != "" {
+		fpRate := r.cfg.TriedFilterFPRate
+		if fpRate <= 0 {
+			fpRate = 0.01
+		}
+		maxBytes := r.cfg.TriedFilterMaxBytes
+		if maxBytes <= 0 {
+			maxBytes = 64 << 20
+		}
+		expected := uint64(r.cfg.BatchSize) << 20 // rough order-of-magnitude sizing hint; maxBytes is the real cap
+		r.filter = newShardedTriedFilter(r.cfg.Workers, expected, fpRate, maxBytes, r.restoredFilter)
+		go r.filter.runMerger()
+	}
+
 	var wg sync.WaitGroup
 	// Workers
 	for i := 0; i < r.cfg.Workers; i++ {
@@ -127,30 +478,47 @@ func (r *Runner) Start(parent context.Context) error {
 			}
 			defer vw.Close()
 
+			report := func(pw string) {
+				r.publishResult(Result{Found: true, Password: pw})
+				if r.cfg.FoundCallback != nil {
+					r.cfg.FoundCallback(pw)
+				}
+				if r.cancel != nil {
+					r.cancel()
+				}
+			}
+
 			for {
 				select {
 				case <-ctx.Done():
+					if pw, found := vw.Flush(); found {
+						report(pw)
+					}
 					return
 				case b, ok := <-jobs:
 					if !ok {
+						if pw, found := vw.Flush(); found {
+							report(pw)
+						}
 						return
 					}
 					// Process batch via backend
 					if ctx.Err() != nil {
 						return
 					}
-					matchIdx, attempts := vw.BatchVerify(b)
-					atomic.AddUint64(&r.counters[id], uint64(attempts))
-					if matchIdx >= 0 && matchIdx < len(b) {
-						pw := b[matchIdx]
-						// Found! publish and cancel
-						r.publishResult(Result{Found: true, Password: pw})
-						if r.cfg.FoundCallback != nil {
-							r.cfg.FoundCallback(pw)
-						}
-						if r.cancel != nil {
-							r.cancel()
+					toTry := b
+					if r.filter != nil {
+						toTry = toTry[:0]
+						for _, pw := range b {
+							if !r.filter.markTried(id, pw) {
+								toTry = append(toTry, pw)
+							}
 						}
+					}
+					pw, found, attempts := vw.BatchVerify(toTry)
+					atomic.AddUint64(&r.counters[id], uint64(attempts))
+					if found {
+						report(pw)
 						return
 					}
 				}
@@ -160,22 +528,18 @@ func (r *Runner) Start(parent context.Context) error {
 
 	// Generator
 	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		seed := time.Now().UnixNano() ^ int64(r.cfg.Workers) ^ int64(len(r.cfg.Alphabet))
-		rng := rand.New(rand.NewSource(seed))
-		for {
-			if ctx.Err() != nil {
-				return
-			}
-			b := generateBatch(r.cfg.Alphabet, r.cfg.MinLen, r.cfg.MaxLen, r.cfg.BatchSize, rng)
-			select {
-			case jobs <- b:
-			case <-ctx.Done():
-				return
-			}
-		}
-	}()
+	switch {
+	case r.cfg.Candidates != nil:
+		go func() {
+			defer wg.Done()
+			r.runCandidateGenerator(ctx, jobs)
+		}()
+	default:
+		go func() {
+			defer wg.Done()
+			r.runCandidateSource(ctx, jobs)
+		}()
+	}
 
 	// Stats publisher
 	wg.Add(1)
@@ -201,6 +565,11 @@ func (r *Runner) Start(parent context.Context) error {
 				default:
 					// drop if UI is slow; next tick will carry new data
 				}
+				if r.cfg.CheckpointPath != "" && r.cfg.Candidates == nil {
+					if err := SaveRunnerCheckpoint(r.cfg.CheckpointPath, r.Snapshot()); err != nil {
+						fmt.Printf("Warning: failed to write checkpoint (%v)\n", err)
+					}
+				}
 			}
 		}
 	}()
@@ -210,6 +579,14 @@ func (r *Runner) Start(parent context.Context) error {
 		wg.Wait()
 		close(jobs)
 		close(r.statsCh)
+		if r.filter != nil {
+			r.filter.close()
+		}
+		if r.cfg.CheckpointPath != "" && r.cfg.Candidates == nil {
+			if err := SaveRunnerCheckpoint(r.cfg.CheckpointPath, r.Snapshot()); err != nil {
+				fmt.Printf("Warning: failed to write final checkpoint (%v)\n", err)
+			}
+		}
 		// If no password found, publish final not found
 		if !r.result.Found {
 			r.publishResult(Result{Found: false})
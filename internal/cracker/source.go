@@ -0,0 +1,45 @@
+package cracker
+
+import (
+	"math/rand"
+	"time"
+)
+
+// BruteForceSource generates random passwords from Alphabet with lengths in [MinLen, MaxLen],
+// the same distribution generateBatch has always used, exposed as a charset.Source so it plugs
+// into archive.Crack, the only charset.Source consumer left now that mask attacks go through
+// candidates.MaskGenerator instead (see cmd/zipcrack's prompt flow).
+type BruteForceSource struct {
+	Alphabet []rune
+	MinLen   int
+	MaxLen   int
+}
+
+// Generate implements charset.Source. It never runs out on its own - callers stop it by
+// returning false from fn.
+func (s BruteForceSource) Generate(fn func(password string) bool) {
+	seed := time.Now().UnixNano() ^ int64(len(s.Alphabet))
+	rng := rand.New(rand.NewSource(seed))
+	al := len(s.Alphabet)
+	if al == 0 {
+		return
+	}
+	minLen, maxLen := s.MinLen, s.MaxLen
+	if maxLen < minLen {
+		maxLen = minLen
+	}
+
+	for {
+		l := minLen
+		if maxLen > minLen {
+			l += rng.Intn(maxLen - minLen + 1)
+		}
+		b := make([]rune, l)
+		for j := 0; j < l; j++ {
+			b[j] = s.Alphabet[rng.Intn(al)]
+		}
+		if !fn(string(b)) {
+			return
+		}
+	}
+}
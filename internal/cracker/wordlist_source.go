@@ -0,0 +1,84 @@
+package cracker
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+)
+
+// WordlistSource streams candidates from a wordlist file, one per line, implementing
+// CandidateSource for Runner's generator goroutine. Unlike RandomSource and MutatorSource it can
+// run out: once every line has been handed out, NextBatch returns 0 on every subsequent call.
+type WordlistSource struct {
+	words []string
+	pos   int
+}
+
+// NewWordlistSource loads every non-blank line of the file at path as a candidate.
+func NewWordlistSource(path string) (*WordlistSource, error) {
+	words, err := LoadWordlist(path)
+	if err != nil {
+		return nil, err
+	}
+	return &WordlistSource{words: words}, nil
+}
+
+// LoadWordlist reads every non-blank line of the file at path into a slice, for callers (like
+// MutatorSource) that need the raw word list rather than a ready-made CandidateSource over it.
+func LoadWordlist(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var words []string
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 64*1024), 1024*1024)
+	for sc.Scan() {
+		line := sc.Text()
+		if line == "" {
+			continue
+		}
+		words = append(words, line)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	return words, nil
+}
+
+// NextBatch implements CandidateSource.
+func (s *WordlistSource) NextBatch(dst []string) int {
+	n := 0
+	for n < len(dst) && s.pos < len(s.words) {
+		dst[n] = s.words[s.pos]
+		s.pos++
+		n++
+	}
+	return n
+}
+
+// wordlistSourceState is WordlistSource's Resumable snapshot: just the offset into words, since
+// the word list itself is reloaded from the same path on resume.
+type wordlistSourceState struct {
+	Pos int `json:"pos"`
+}
+
+// State implements Resumable.
+func (s *WordlistSource) State() json.RawMessage {
+	data, _ := json.Marshal(wordlistSourceState{Pos: s.pos})
+	return data
+}
+
+// Restore implements Resumable.
+func (s *WordlistSource) Restore(state json.RawMessage) error {
+	var st wordlistSourceState
+	if err := json.Unmarshal(state, &st); err != nil {
+		return err
+	}
+	if st.Pos >= 0 && st.Pos <= len(s.words) {
+		s.pos = st.Pos
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package cracker
+
+import (
+	"context"
+	"fmt"
+
+	"zipcrack/internal/transport"
+	"zipcrack/internal/verifier"
+)
+
+// WorkerConfig configures RunWorker, the remote counterpart to Start's local worker goroutines:
+// it consumes batches from a coordinator over Transport instead of a local jobs channel.
+type WorkerConfig struct {
+	ZipBytes  []byte
+	Backend   string
+	WorkerID  string
+	Transport transport.JobTransport
+}
+
+// RunWorker loops Transport.ConsumeBatch, runs each batch through the existing
+// verifier.Worker.BatchVerify, acks only once BatchVerify returns (a worker that crashes mid-batch
+// never acks; KafkaTransport redelivers such a batch, TCPTransport does not), and
+// reports the outcome via Transport.PublishResult. It returns nil when the coordinator publishes a
+// cancel marker (found elsewhere) or ctx is done, and a non-nil error on any other failure.
+func RunWorker(ctx context.Context, cfg WorkerConfig) error {
+	var v verifier.Verifier
+	switch cfg.Backend {
+	case "vulkan":
+		ver, err := verifier.NewVulkan(verifier.VulkanConfig{Strategy: verifier.MemoryStrategyAuto})
+		if err != nil {
+			fmt.Printf("Warning: Vulkan initialization failed (%v)\n", err)
+			fmt.Println("Falling back to CPU backend...")
+			v = verifier.NewCPU()
+		} else {
+			v = ver
+		}
+	default:
+		v = verifier.NewCPU()
+	}
+
+	vw, err := v.NewWorker(cfg.ZipBytes)
+	if err != nil {
+		return fmt.Errorf("worker: failed to init verifier: %w", err)
+	}
+	defer vw.Close()
+
+	for {
+		select {
+		case <-cfg.Transport.Cancelled():
+			return nil
+		case <-ctx.Done():
+			return nil
+		default:
+		}
+
+		b, ack, err := cfg.Transport.ConsumeBatch(ctx)
+		if err != nil {
+			select {
+			case <-cfg.Transport.Cancelled():
+				return nil
+			case <-ctx.Done():
+				return nil
+			default:
+			}
+			return fmt.Errorf("worker: failed to consume batch: %w", err)
+		}
+
+		pw, found, attempts := vw.BatchVerify(b.Passwords)
+		if err := ack(); err != nil {
+			return fmt.Errorf("worker: failed to ack batch %d: %w", b.JobID, err)
+		}
+
+		res := transport.WorkerResult{WorkerID: cfg.WorkerID, JobID: b.JobID, Attempts: attempts, Found: found, Password: pw}
+		if err := cfg.Transport.PublishResult(ctx, res); err != nil {
+			return fmt.Errorf("worker: failed to publish result for batch %d: %w", b.JobID, err)
+		}
+		if found {
+			return nil
+		}
+	}
+}
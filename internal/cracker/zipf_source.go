@@ -0,0 +1,89 @@
+package cracker
+
+import (
+	"encoding/json"
+	"math/rand"
+	"time"
+)
+
+// ZipfSource keeps RandomSource's uniform password-length distribution but weights character
+// choice by a Zipf-Mandelbrot distribution over the alphabet (ordered most- to least-frequent)
+// instead of choosing uniformly - useful when no training wordlist is available but the target is
+// known to be human-chosen, which skews heavily toward common letters and digits.
+type ZipfSource struct {
+	// Alphabet must be ordered most- to least-frequent; Alphabet[0] is the most likely character.
+	Alphabet []rune
+	MinLen   int
+	MaxLen   int
+
+	rng   *rand.Rand
+	zipf  *rand.Zipf
+	seed  int64
+	zipfS float64
+	zipfQ float64
+}
+
+// NewZipfSource builds a ZipfSource. s and q are math/rand.NewZipf's shape/offset parameters
+// (s must be > 1; q >= 1), controlling how sharply character frequency falls off by rank.
+func NewZipfSource(alphabet []rune, minLen, maxLen int, s, q float64) *ZipfSource {
+	seed := time.Now().UnixNano()
+	rng := rand.New(rand.NewSource(seed))
+	return &ZipfSource{
+		Alphabet: alphabet,
+		MinLen:   minLen,
+		MaxLen:   maxLen,
+		rng:      rng,
+		zipf:     rand.NewZipf(rng, s, q, uint64(len(alphabet)-1)),
+		seed:     seed,
+		zipfS:    s,
+		zipfQ:    q,
+	}
+}
+
+// NextBatch implements CandidateSource.
+func (s *ZipfSource) NextBatch(dst []string) int {
+	al := len(s.Alphabet)
+	if al == 0 {
+		return 0
+	}
+	minLen, maxLen := s.MinLen, s.MaxLen
+	if maxLen < minLen {
+		maxLen = minLen
+	}
+	for i := range dst {
+		l := minLen
+		if maxLen > minLen {
+			l += s.rng.Intn(maxLen - minLen + 1)
+		}
+		b := make([]rune, l)
+		for j := 0; j < l; j++ {
+			b[j] = s.Alphabet[s.zipf.Uint64()]
+		}
+		dst[i] = string(b)
+	}
+	return len(dst)
+}
+
+// zipfSourceState is ZipfSource's Resumable snapshot; see RandomSource's State for why reseeding
+// rather than replaying exact position is sufficient here.
+type zipfSourceState struct {
+	Seed int64 `json:"seed"`
+}
+
+// State implements Resumable.
+func (s *ZipfSource) State() json.RawMessage {
+	data, _ := json.Marshal(zipfSourceState{Seed: s.seed})
+	return data
+}
+
+// Restore implements Resumable.
+func (s *ZipfSource) Restore(state json.RawMessage) error {
+	var st zipfSourceState
+	if err := json.Unmarshal(state, &st); err != nil {
+		return err
+	}
+	s.seed = st.Seed
+	s.rng = rand.New(rand.NewSource(st.Seed))
+	s.zipf = rand.NewZipf(s.rng, s.zipfS, s.zipfQ, uint64(len(s.Alphabet)-1))
+	return nil
+}
@@ -0,0 +1,50 @@
+package cracker
+
+import "testing"
+
+func TestZipfSourceNextBatchRespectsAlphabetAndLength(t *testing.T) {
+	alphabet := []rune("etaoinshrdlu")
+	s := NewZipfSource(alphabet, 3, 5, 1.5, 1.0)
+
+	dst := make([]string, 100)
+	n := s.NextBatch(dst)
+	if n != len(dst) {
+		t.Fatalf("NextBatch returned %d, want %d", n, len(dst))
+	}
+
+	inAlphabet := make(map[rune]bool, len(alphabet))
+	for _, r := range alphabet {
+		inAlphabet[r] = true
+	}
+	for _, pw := range dst {
+		if l := len([]rune(pw)); l < 3 || l > 5 {
+			t.Fatalf("sampled password %q has length %d, want [3,5]", pw, l)
+		}
+		for _, r := range pw {
+			if !inAlphabet[r] {
+				t.Fatalf("sampled password %q contains rune %q outside the alphabet", pw, r)
+			}
+		}
+	}
+}
+
+func TestZipfSourceNextBatchEmptyAlphabet(t *testing.T) {
+	s := NewZipfSource(nil, 1, 4, 1.5, 1.0)
+	dst := make([]string, 5)
+	if n := s.NextBatch(dst); n != 0 {
+		t.Fatalf("NextBatch with empty alphabet returned %d, want 0", n)
+	}
+}
+
+func TestZipfSourceStateRoundTrip(t *testing.T) {
+	s := NewZipfSource([]rune("abcdef"), 1, 4, 1.5, 1.0)
+	state := s.State()
+
+	other := NewZipfSource([]rune("abcdef"), 1, 4, 1.5, 1.0)
+	if err := other.Restore(state); err != nil {
+		t.Fatalf("Restore: %v", err)
+	}
+	if other.seed != s.seed {
+		t.Fatalf("Restore did not restore the seed: got %d, want %d", other.seed, s.seed)
+	}
+}
@@ -0,0 +1,41 @@
+package gpu
+
+import (
+	"bytes"
+	"errors"
+
+	yzip "github.com/yeka/zip"
+
+	"zipcrack/internal/verifier"
+)
+
+// smallestZipCryptoEntry picks the smallest traditional-ZipCrypto encrypted entry, mirroring the
+// target selection every other backend in this codebase uses (see
+// verifier.findSmallestEncryptedIndex), narrowed to entries this package's kernel can actually
+// check.
+func smallestZipCryptoEntry(zipBytes []byte) (int, error) {
+	br := bytes.NewReader(zipBytes)
+	zr, err := yzip.NewReader(br, int64(len(zipBytes)))
+	if err != nil {
+		return -1, err
+	}
+
+	target := -1
+	var targetSize uint64 = ^uint64(0)
+	for i, f := range zr.File {
+		if f.FileInfo().IsDir() || !f.IsEncrypted() {
+			continue
+		}
+		if _, err := verifier.ParseZipCryptoInfoAt(zipBytes, i); err != nil {
+			continue
+		}
+		if sz := f.UncompressedSize64; sz < targetSize {
+			target = i
+			targetSize = sz
+		}
+	}
+	if target == -1 {
+		return -1, errors.New("gpu: zip has no traditional ZipCrypto entries to crack")
+	}
+	return target, nil
+}
@@ -0,0 +1,221 @@
+//go:build cgo && opencl
+
+// Package gpu implements an OpenCL-accelerated verifier.Verifier backend for traditional
+// ZipCrypto entries, matching the keystream check ZipCryptoInfo's doc comment has always
+// described as "verification on GPU". It mirrors verifier's Vulkan backend: a graceful error
+// return when no device is available, so callers can fall back to the CPU backend the same way
+// runner.go already falls back from Vulkan.
+//
+// This file requires both cgo and an installed OpenCL SDK (CL/cl.h, libOpenCL), neither of which
+// every contributor has, so it's opt-in behind the "opencl" build tag rather than compiled
+// whenever cgo happens to be enabled: `go build -tags opencl ./...`. Without that tag,
+// opencl_stub.go provides the same NewOpenCL signature reporting the backend as unavailable.
+package gpu
+
+/*
+#cgo LDFLAGS: -lOpenCL
+#include <CL/cl.h>
+#include <stdlib.h>
+*/
+import "C"
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"unsafe"
+
+	"zipcrack/internal/verifier"
+)
+
+//go:embed kernel.cl
+var kernelSource string
+
+// DefaultBatchSize is the number of candidate passwords staged per kernel launch.
+const DefaultBatchSize = 1 << 20
+
+// maxPasswordBytes bounds how much host-side password data one batch stages, keeping the
+// passwords buffer's size predictable regardless of individual candidate lengths.
+const maxPasswordBytes = DefaultBatchSize * 16
+
+// openCLVerifier holds the platform/device/context/program shared across all of a run's workers.
+type openCLVerifier struct {
+	device  C.cl_device_id
+	context C.cl_context
+	program C.cl_program
+}
+
+// NewOpenCL selects the first available OpenCL GPU device, builds the ZipCrypto kernel, and
+// returns a verifier.Verifier backed by it. It returns an error (never panics) when no OpenCL
+// platform/device is present or the kernel fails to build, so callers can degrade to the CPU
+// verifier.
+func NewOpenCL() (verifier.Verifier, error) {
+	var platform C.cl_platform_id
+	if C.clGetPlatformIDs(1, &platform, nil) != C.CL_SUCCESS {
+		return nil, errors.New("gpu: no OpenCL platform available")
+	}
+
+	var device C.cl_device_id
+	if C.clGetDeviceIDs(platform, C.CL_DEVICE_TYPE_GPU, 1, &device, nil) != C.CL_SUCCESS {
+		return nil, errors.New("gpu: no OpenCL GPU device available")
+	}
+
+	var errCode C.cl_int
+	context := C.clCreateContext(nil, 1, &device, nil, nil, &errCode)
+	if errCode != C.CL_SUCCESS {
+		return nil, fmt.Errorf("gpu: clCreateContext failed: %d", int(errCode))
+	}
+
+	csrc := C.CString(kernelSource)
+	defer C.free(unsafe.Pointer(csrc))
+	srcLen := C.size_t(len(kernelSource))
+	program := C.clCreateProgramWithSource(context, 1, &csrc, &srcLen, &errCode)
+	if errCode != C.CL_SUCCESS {
+		C.clReleaseContext(context)
+		return nil, fmt.Errorf("gpu: clCreateProgramWithSource failed: %d", int(errCode))
+	}
+
+	if C.clBuildProgram(program, 1, &device, nil, nil, nil) != C.CL_SUCCESS {
+		var logLen C.size_t
+		C.clGetProgramBuildInfo(program, device, C.CL_PROGRAM_BUILD_LOG, 0, nil, &logLen)
+		buildLog := make([]byte, logLen)
+		if logLen > 0 {
+			C.clGetProgramBuildInfo(program, device, C.CL_PROGRAM_BUILD_LOG, logLen, unsafe.Pointer(&buildLog[0]), nil)
+		}
+		C.clReleaseProgram(program)
+		C.clReleaseContext(context)
+		return nil, fmt.Errorf("gpu: kernel build failed: %s", string(buildLog))
+	}
+
+	return &openCLVerifier{device: device, context: context, program: program}, nil
+}
+
+// NewWorker parses the target entry's ZipCrypto metadata and sets up a dedicated command queue,
+// kernel instance, and device buffers for one goroutine. Workers are not safe to share across
+// goroutines, matching every other verifier.Worker implementation in this codebase.
+func (v *openCLVerifier) NewWorker(zipBytes []byte) (verifier.Worker, error) {
+	target, err := smallestZipCryptoEntry(zipBytes)
+	if err != nil {
+		return nil, err
+	}
+	zcInfo, err := verifier.ParseZipCryptoInfoAt(zipBytes, target)
+	if err != nil {
+		return nil, fmt.Errorf("gpu: target entry is not traditional ZipCrypto: %w", err)
+	}
+
+	var errCode C.cl_int
+	queue := C.clCreateCommandQueue(v.context, v.device, 0, &errCode)
+	if errCode != C.CL_SUCCESS {
+		return nil, fmt.Errorf("gpu: clCreateCommandQueue failed: %d", int(errCode))
+	}
+
+	ckernel := C.CString("zipcrypto_check")
+	defer C.free(unsafe.Pointer(ckernel))
+	kernel := C.clCreateKernel(v.program, ckernel, &errCode)
+	if errCode != C.CL_SUCCESS {
+		C.clReleaseCommandQueue(queue)
+		return nil, fmt.Errorf("gpu: clCreateKernel failed: %d", int(errCode))
+	}
+
+	w := &openCLWorker{
+		v:           v,
+		queue:       queue,
+		kernel:      kernel,
+		zcInfo:      zcInfo,
+		hostData:    make([]byte, maxPasswordBytes),
+		hostOffsets: make([]int32, DefaultBatchSize),
+		hostLens:    make([]int32, DefaultBatchSize),
+		hostResults: make([]byte, DefaultBatchSize),
+	}
+	return w, nil
+}
+
+type openCLWorker struct {
+	v      *openCLVerifier
+	queue  C.cl_command_queue
+	kernel C.cl_kernel
+	zcInfo *verifier.ZipCryptoInfo
+
+	// Host-side staging buffers, reused across batches so BatchVerify doesn't allocate per call.
+	hostData    []byte
+	hostOffsets []int32
+	hostLens    []int32
+	hostResults []byte
+}
+
+// BatchVerify packs batch into the worker's staging buffers as (offset, length) pairs into a
+// single flat byte buffer, runs the ZipCrypto check-byte kernel over all of them in one launch,
+// and reports the first candidate whose decrypted header matches.
+func (w *openCLWorker) BatchVerify(batch []string) (password string, found bool, attempts int) {
+	if len(batch) == 0 {
+		return "", false, 0
+	}
+	n := len(batch)
+	if n > DefaultBatchSize {
+		n = DefaultBatchSize
+	}
+
+	cursor := 0
+	for i := 0; i < n; i++ {
+		pw := batch[i]
+		if cursor+len(pw) > len(w.hostData) {
+			n = i
+			break
+		}
+		copy(w.hostData[cursor:], pw)
+		w.hostOffsets[i] = int32(cursor)
+		w.hostLens[i] = int32(len(pw))
+		cursor += len(pw)
+	}
+	if n == 0 {
+		return "", false, 0
+	}
+
+	var errCode C.cl_int
+	passwordsBuf := C.clCreateBuffer(w.v.context, C.CL_MEM_READ_ONLY|C.CL_MEM_COPY_HOST_PTR,
+		C.size_t(cursor), unsafe.Pointer(&w.hostData[0]), &errCode)
+	offsetsBuf := C.clCreateBuffer(w.v.context, C.CL_MEM_READ_ONLY|C.CL_MEM_COPY_HOST_PTR,
+		C.size_t(n)*C.size_t(unsafe.Sizeof(int32(0))), unsafe.Pointer(&w.hostOffsets[0]), &errCode)
+	lengthsBuf := C.clCreateBuffer(w.v.context, C.CL_MEM_READ_ONLY|C.CL_MEM_COPY_HOST_PTR,
+		C.size_t(n)*C.size_t(unsafe.Sizeof(int32(0))), unsafe.Pointer(&w.hostLens[0]), &errCode)
+	header := w.zcInfo.EncryptedHeader
+	headerBuf := C.clCreateBuffer(w.v.context, C.CL_MEM_READ_ONLY|C.CL_MEM_COPY_HOST_PTR,
+		12, unsafe.Pointer(&header[0]), &errCode)
+	resultsBuf := C.clCreateBuffer(w.v.context, C.CL_MEM_WRITE_ONLY,
+		C.size_t(n), nil, &errCode)
+	defer C.clReleaseMemObject(passwordsBuf)
+	defer C.clReleaseMemObject(offsetsBuf)
+	defer C.clReleaseMemObject(lengthsBuf)
+	defer C.clReleaseMemObject(headerBuf)
+	defer C.clReleaseMemObject(resultsBuf)
+
+	C.clSetKernelArg(w.kernel, 0, C.size_t(unsafe.Sizeof(passwordsBuf)), unsafe.Pointer(&passwordsBuf))
+	C.clSetKernelArg(w.kernel, 1, C.size_t(unsafe.Sizeof(offsetsBuf)), unsafe.Pointer(&offsetsBuf))
+	C.clSetKernelArg(w.kernel, 2, C.size_t(unsafe.Sizeof(lengthsBuf)), unsafe.Pointer(&lengthsBuf))
+	C.clSetKernelArg(w.kernel, 3, C.size_t(unsafe.Sizeof(headerBuf)), unsafe.Pointer(&headerBuf))
+	checkByte := C.uchar(w.zcInfo.CheckByte)
+	C.clSetKernelArg(w.kernel, 4, 1, unsafe.Pointer(&checkByte))
+	C.clSetKernelArg(w.kernel, 5, C.size_t(unsafe.Sizeof(resultsBuf)), unsafe.Pointer(&resultsBuf))
+
+	globalSize := C.size_t(n)
+	if C.clEnqueueNDRangeKernel(w.queue, w.kernel, 1, nil, &globalSize, nil, 0, nil, nil) != C.CL_SUCCESS {
+		return "", false, n
+	}
+	C.clEnqueueReadBuffer(w.queue, resultsBuf, C.CL_TRUE, 0, C.size_t(n), unsafe.Pointer(&w.hostResults[0]), 0, nil, nil)
+
+	for i := 0; i < n; i++ {
+		if w.hostResults[i] != 0 {
+			return batch[i], true, n
+		}
+	}
+	return "", false, n
+}
+
+// Flush is a no-op: BatchVerify's clEnqueueReadBuffer call blocks until the launch it kicked off
+// has completed, so nothing is ever left outstanding between calls.
+func (w *openCLWorker) Flush() (string, bool) { return "", false }
+
+func (w *openCLWorker) Close() {
+	C.clReleaseKernel(w.kernel)
+	C.clReleaseCommandQueue(w.queue)
+}
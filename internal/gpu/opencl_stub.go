@@ -0,0 +1,20 @@
+//go:build !cgo || !opencl
+
+package gpu
+
+import (
+	"errors"
+
+	"zipcrack/internal/verifier"
+)
+
+// DefaultBatchSize is the number of candidate passwords staged per kernel launch.
+const DefaultBatchSize = 1 << 20
+
+// NewOpenCL reports that no OpenCL backend was compiled in. Building the real backend requires
+// both cgo and the "opencl" build tag (see opencl.go); by default, or without an OpenCL SDK
+// available, neither is set and there's no way to load libOpenCL. Callers should fall back to the
+// CPU verifier the same way they would if NewOpenCL found no device at runtime.
+func NewOpenCL() (verifier.Verifier, error) {
+	return nil, errors.New("gpu: built without the \"opencl\" tag (or without cgo), OpenCL backend unavailable")
+}
@@ -0,0 +1,205 @@
+// Package plaintext implements a known-plaintext (Biham-Kocher style) attack on the traditional
+// PKWARE ZipCrypto stream cipher, as an alternative to brute forcing the password space when an
+// attacker already knows some bytes of an entry's decompressed content.
+package plaintext
+
+import (
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"sort"
+)
+
+// minKnownBytes is the shortest known-plaintext window this package will attempt a recovery
+// against. Shorter windows leave key2's top 16 bits too unconstrained for the backward search
+// in RecoverKey2 to have a realistic chance of narrowing to a single candidate.
+const minKnownBytes = 13
+
+// maxCandidates bounds the candidate beam carried between steps of RecoverKey2. In practice the
+// keystream-byte constraint at every step keeps the beam roughly stable in size rather than
+// growing; the cap exists only to keep a pathological run finite, truncating (rather than
+// sampling) any beam that exceeds it.
+const maxCandidates = 1 << 20
+
+// Attack holds the keystream (ciphertext XOR known plaintext) derived from a known-plaintext
+// window against a ZipCrypto-encrypted entry.
+type Attack struct {
+	keystream []byte
+}
+
+// NewAttack builds an Attack from an entry's raw ciphertext bytes and the plaintext the attacker
+// already knows occupies that same range (e.g. a known file-format signature at the start of the
+// decompressed stream). ciphertext and knownPlaintext must be the same length.
+func NewAttack(ciphertext, knownPlaintext []byte) (*Attack, error) {
+	if len(ciphertext) != len(knownPlaintext) {
+		return nil, errors.New("plaintext: ciphertext and known plaintext must be the same length")
+	}
+	if len(ciphertext) < minKnownBytes {
+		return nil, fmt.Errorf("plaintext: need at least %d bytes of known plaintext, got %d", minKnownBytes, len(ciphertext))
+	}
+
+	keystream := make([]byte, len(ciphertext))
+	for i := range keystream {
+		keystream[i] = ciphertext[i] ^ knownPlaintext[i]
+	}
+	return &Attack{keystream: keystream}, nil
+}
+
+// crc32Step mirrors verifier.ZipCryptoVerifier's key0/key2 update: crc32.IEEETable is PKZIP's
+// CRC32 table too, so it's reused directly rather than hand-rolled.
+func crc32Step(key uint32, b byte) uint32 {
+	return crc32.IEEETable[byte(key)^b] ^ (key >> 8)
+}
+
+// invertCrc32Step returns every 32-bit key for which crc32Step(key, fed) == out, given fed.
+// Since key>>8 always has a zero top byte, only the guesses of the input's low byte (out of 256)
+// that also leave out^table[idx] with a zero top byte are valid, so this returns a single
+// candidate the overwhelming majority of the time.
+func invertCrc32Step(out uint32, fed byte) []uint32 {
+	var candidates []uint32
+	for low := 0; low < 256; low++ {
+		top := out ^ crc32.IEEETable[byte(low)^fed]
+		if top&0xFF000000 != 0 {
+			continue
+		}
+		candidates = append(candidates, (top<<8)|uint32(low))
+	}
+	return candidates
+}
+
+// key1Step mirrors verifier.ZipCryptoVerifier's key1 update.
+func key1Step(key1 uint32, key0LSB byte) uint32 {
+	return (key1+uint32(key0LSB))*0x08088405 + 1
+}
+
+// low16ByKeystream maps a ZipCrypto keystream byte to every 16-bit value the low half of key2
+// could hold to produce it; the top 16 bits of key2 never affect the keystream byte.
+var low16ByKeystream [256][]uint16
+
+func init() {
+	for low := 0; low < 1<<16; low++ {
+		low16ByKeystream[keystreamByte(uint32(low))] = append(low16ByKeystream[keystreamByte(uint32(low))], uint16(low))
+	}
+}
+
+func keystreamByte(key2 uint32) byte {
+	temp := key2 | 3
+	return byte((temp * (temp ^ 1)) >> 8)
+}
+
+// RecoverKey2 reconstructs the key2 register as it stood when the first byte of the known-plaintext
+// window was encrypted, by working backwards from the window's last byte: seed a beam with every key2 whose low 16 bits reproduce the last recorded
+// keystream byte (top 16 bits unconstrained), then repeatedly invert one crc32Step per beam entry
+// over all 256 possible "fed" bytes (key1's unknown top byte at that position) and keep only the
+// predecessors whose own keystream byte matches. The keystream constraint at every step is what
+// keeps the beam from exploding; it converges to a single candidate only once the window is long
+// enough to have pinned down all 32 bits. ok is false if it hasn't (window too short, or the
+// candidate cap was hit before convergence).
+func (a *Attack) RecoverKey2() (key2 uint32, ok bool) {
+	n := len(a.keystream)
+
+	seedLow := low16ByKeystream[a.keystream[n-1]]
+	candidates := make([]uint32, 0, maxCandidates)
+	for _, low16 := range seedLow {
+		for top16 := 0; top16 < 1<<16; top16++ {
+			candidates = append(candidates, uint32(low16)|uint32(top16)<<16)
+			if len(candidates) >= maxCandidates {
+				break
+			}
+		}
+		if len(candidates) >= maxCandidates {
+			break
+		}
+	}
+
+	for i := n - 2; i >= 0 && len(candidates) > 0; i-- {
+		want := a.keystream[i]
+		next := make([]uint32, 0, len(candidates))
+		for _, succ := range candidates {
+			for fed := 0; fed < 256; fed++ {
+				for _, pred := range invertCrc32Step(succ, byte(fed)) {
+					if keystreamByte(pred) == want {
+						next = append(next, pred)
+					}
+				}
+			}
+			if len(next) >= maxCandidates {
+				break
+			}
+		}
+		candidates = dedupe(next)
+	}
+
+	if len(candidates) != 1 {
+		return 0, false
+	}
+	return candidates[0], true
+}
+
+func dedupe(xs []uint32) []uint32 {
+	if len(xs) < 2 {
+		return xs
+	}
+	sort.Slice(xs, func(i, j int) bool { return xs[i] < xs[j] })
+	out := xs[:1]
+	for _, x := range xs[1:] {
+		if x != out[len(out)-1] {
+			out = append(out, x)
+		}
+	}
+	return out
+}
+
+// deriveKey2 runs the ordinary ZipCrypto key schedule (see verifier.ZipCryptoVerifier) over
+// password and returns the resulting key2, without decrypting anything.
+func deriveKey2(password []byte) uint32 {
+	key0 := uint32(0x12345678)
+	key1 := uint32(0x23456789)
+	key2 := uint32(0x34567890)
+	for _, b := range password {
+		key0 = crc32Step(key0, b)
+		key1 = key1Step(key1, byte(key0))
+		key2 = crc32Step(key2, byte(key1>>24))
+	}
+	return key2
+}
+
+// RecoverPassword brute forces candidate passwords of length [1, maxLen] built from charset,
+// returning the first whose derived key2 (after consuming the whole password) matches the key2
+// recovered by RecoverKey2. This only applies when the known-plaintext window given to NewAttack
+// begins at the first byte of the entry's encrypted data (the first byte of its 12-byte encryption
+// header) - that's the only case in which "key2 after consuming the password" and the key2
+// RecoverKey2 returns (the state at the window's first byte) refer to the same cipher state. A
+// wrong password matches the 32-bit key2 about once in 2^32 tries, so when charset and maxLen give
+// a keyspace near or above 2^32 the first match can be a false positive; RecoverPassword does no
+// further decrypt-and-verify pass.
+func (a *Attack) RecoverPassword(charset []byte, maxLen int) (string, bool) {
+	if len(charset) == 0 || maxLen <= 0 {
+		return "", false
+	}
+	target, ok := a.RecoverKey2()
+	if !ok {
+		return "", false
+	}
+
+	buf := make([]byte, maxLen)
+	for length := 1; length <= maxLen; length++ {
+		if found := bruteForce(charset, buf[:length], 0, target); found {
+			return string(buf[:length]), true
+		}
+	}
+	return "", false
+}
+
+func bruteForce(charset, buf []byte, pos int, target uint32) bool {
+	if pos == len(buf) {
+		return deriveKey2(buf) == target
+	}
+	for _, c := range charset {
+		buf[pos] = c
+		if bruteForce(charset, buf, pos+1, target) {
+			return true
+		}
+	}
+	return false
+}
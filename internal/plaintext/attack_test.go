@@ -0,0 +1,110 @@
+package plaintext
+
+import "testing"
+
+func TestNewAttackRejectsMismatchedLengths(t *testing.T) {
+	if _, err := NewAttack(make([]byte, 13), make([]byte, 12)); err == nil {
+		t.Fatal("expected error for mismatched ciphertext/plaintext lengths")
+	}
+}
+
+func TestNewAttackRejectsShortWindow(t *testing.T) {
+	if _, err := NewAttack(make([]byte, minKnownBytes-1), make([]byte, minKnownBytes-1)); err == nil {
+		t.Fatal("expected error for a known-plaintext window shorter than minKnownBytes")
+	}
+}
+
+func TestNewAttackAcceptsMinimumWindow(t *testing.T) {
+	attack, err := NewAttack(make([]byte, minKnownBytes), make([]byte, minKnownBytes))
+	if err != nil {
+		t.Fatalf("NewAttack: %v", err)
+	}
+	if len(attack.keystream) != minKnownBytes {
+		t.Fatalf("keystream length = %d, want %d", len(attack.keystream), minKnownBytes)
+	}
+}
+
+// crc32Step/invertCrc32Step/key1Step/keystreamByte are the building blocks RecoverKey2's backward
+// beam search chains together; the full search over real data is exponential enough that driving
+// it end-to-end isn't practical as a fast unit test, so these check the primitives it relies on
+// instead.
+
+func TestCrc32StepRoundTripsThroughInvert(t *testing.T) {
+	for _, key := range []uint32{0, 1, 0x12345678, 0xdeadbeef, 0xffffffff} {
+		for _, b := range []byte{0x00, 0x41, 0xff} {
+			out := crc32Step(key, b)
+			candidates := invertCrc32Step(out, b)
+			found := false
+			for _, c := range candidates {
+				if c == key {
+					found = true
+					break
+				}
+			}
+			if !found {
+				t.Fatalf("invertCrc32Step(crc32Step(%#x, %#x), %#x) = %v, missing original key %#x", key, b, b, candidates, key)
+			}
+		}
+	}
+}
+
+func TestInvertCrc32StepCandidatesReproduceOutput(t *testing.T) {
+	out := crc32Step(0x9e3779b9, 0x7a)
+	for _, candidate := range invertCrc32Step(out, 0x7a) {
+		if got := crc32Step(candidate, 0x7a); got != out {
+			t.Fatalf("candidate %#x doesn't reproduce out: crc32Step = %#x, want %#x", candidate, got, out)
+		}
+	}
+}
+
+func TestKey1StepIsDeterministic(t *testing.T) {
+	got := key1Step(0x23456789, 0x42)
+	want := key1Step(0x23456789, 0x42)
+	if got != want {
+		t.Fatalf("key1Step is not deterministic: %#x != %#x", got, want)
+	}
+	if got == key1Step(0x23456789, 0x43) {
+		t.Fatal("key1Step produced the same output for different input bytes")
+	}
+}
+
+func TestLow16ByKeystreamCoversEveryKeystreamByte(t *testing.T) {
+	for b := 0; b < 256; b++ {
+		if len(low16ByKeystream[b]) == 0 {
+			t.Fatalf("keystream byte %#x has no low16 candidates", b)
+		}
+		for _, low16 := range low16ByKeystream[b] {
+			if got := keystreamByte(uint32(low16)); got != byte(b) {
+				t.Fatalf("keystreamByte(%#x) = %#x, want %#x", low16, got, b)
+			}
+		}
+	}
+}
+
+func TestDedupe(t *testing.T) {
+	got := dedupe([]uint32{3, 1, 2, 1, 3, 3})
+	want := []uint32{1, 2, 3}
+	if len(got) != len(want) {
+		t.Fatalf("dedupe = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("dedupe = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestDeriveKey2MatchesManualKeySchedule(t *testing.T) {
+	password := []byte("hunter2")
+	key0 := uint32(0x12345678)
+	key1 := uint32(0x23456789)
+	key2 := uint32(0x34567890)
+	for _, b := range password {
+		key0 = crc32Step(key0, b)
+		key1 = key1Step(key1, byte(key0))
+		key2 = crc32Step(key2, byte(key1>>24))
+	}
+	if got := deriveKey2(password); got != key2 {
+		t.Fatalf("deriveKey2(%q) = %#x, want %#x", password, got, key2)
+	}
+}
@@ -0,0 +1,309 @@
+package transport
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/IBM/sarama"
+)
+
+// KafkaTransport implements JobTransport over a Kafka-style broker via Sarama, for worker fleets
+// large enough that TCPTransport's one-connection-per-worker model stops scaling. It uses three
+// topics under topicPrefix: "-batches" (coordinator producer, worker consumer group), "-results"
+// (worker producer, coordinator consumer), and "-control" (coordinator producer, worker consumer;
+// carries only the cancel marker).
+type KafkaTransport struct {
+	isCoordinator bool
+	workerID      string
+
+	producer sarama.SyncProducer
+	consumer sarama.ConsumerGroup // workers only, for the batches topic
+	client   sarama.Client
+
+	batchesTopic string
+	resultsTopic string
+	controlTopic string
+
+	// Worker-side state.
+	batches      chan Batch
+	pendingAcks  sync.Map // JobID -> func() that marks the batch's message on its session, run on ack
+	cancelled    chan struct{}
+	cancelOnce   sync.Once
+	consumeGroup string
+
+	// Coordinator-side state.
+	resultConsumer sarama.ConsumerGroup
+}
+
+// kafkaBatchEnvelope/kafkaControlEnvelope are the JSON payloads written to the batches and control
+// topics (a WorkerResult is marshalled to the results topic as is); Sarama itself is
+// payload-format agnostic, so this project picks JSON for the same reason the on-disk Checkpoint
+// format does - easy to inspect with standard tools during a long-running distributed crack.
+type kafkaBatchEnvelope struct {
+	Batch Batch `json:"batch"`
+}
+
+type kafkaControlEnvelope struct {
+	Cancel bool `json:"cancel"`
+}
+
+// NewKafkaCoordinator builds a KafkaTransport that publishes batches/control and consumes results.
+func NewKafkaCoordinator(brokers []string, topicPrefix string) (*KafkaTransport, error) {
+	cfg := sarama.NewConfig()
+	cfg.Producer.Return.Successes = true
+	cfg.Version = sarama.V2_8_0_0
+
+	client, err := sarama.NewClient(brokers, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("transport: failed to connect to kafka brokers: %w", err)
+	}
+	producer, err := sarama.NewSyncProducerFromClient(client)
+	if err != nil {
+		client.Close()
+		return nil, fmt.Errorf("transport: failed to create kafka producer: %w", err)
+	}
+	resultConsumer, err := sarama.NewConsumerGroupFromClient(topicPrefix+"-coordinator", client)
+	if err != nil {
+		client.Close()
+		return nil, fmt.Errorf("transport: failed to create kafka results consumer group: %w", err)
+	}
+
+	return &KafkaTransport{
+		isCoordinator:  true,
+		client:         client,
+		producer:       producer,
+		resultConsumer: resultConsumer,
+		batchesTopic:   topicPrefix + "-batches",
+		resultsTopic:   topicPrefix + "-results",
+		controlTopic:   topicPrefix + "-control",
+	}, nil
+}
+
+// NewKafkaWorker builds a KafkaTransport that consumes batches/control and publishes results.
+// workerID tags every WorkerResult this instance publishes so the coordinator can attribute
+// Stats.PerThread to a stable slot across reconnects.
+func NewKafkaWorker(brokers []string, topicPrefix, workerID string) (*KafkaTransport, error) {
+	cfg := sarama.NewConfig()
+	cfg.Producer.Return.Successes = true
+	cfg.Consumer.Offsets.Initial = sarama.OffsetNewest
+	cfg.Version = sarama.V2_8_0_0
+
+	client, err := sarama.NewClient(brokers, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("transport: failed to connect to kafka brokers: %w", err)
+	}
+	producer, err := sarama.NewSyncProducerFromClient(client)
+	if err != nil {
+		client.Close()
+		return nil, fmt.Errorf("transport: failed to create kafka producer: %w", err)
+	}
+	// Every worker joins the same consumer group on the batches topic, so Kafka's own partition
+	// assignment is what load-balances batches across the pool instead of this package
+	// implementing its own round-robin.
+	group, err := sarama.NewConsumerGroupFromClient(topicPrefix+"-workers", client)
+	if err != nil {
+		client.Close()
+		return nil, fmt.Errorf("transport: failed to create kafka batches consumer group: %w", err)
+	}
+
+	t := &KafkaTransport{
+		isCoordinator: false,
+		workerID:      workerID,
+		client:        client,
+		producer:      producer,
+		consumer:      group,
+		batchesTopic:  topicPrefix + "-batches",
+		resultsTopic:  topicPrefix + "-results",
+		controlTopic:  topicPrefix + "-control",
+		batches:       make(chan Batch, 8),
+		cancelled:     make(chan struct{}),
+	}
+	go t.consumeLoop()
+	return t, nil
+}
+
+// batchConsumerHandler adapts sarama.ConsumerGroupHandler to feed decoded batches and pending acks
+// into the owning KafkaTransport.
+type batchConsumerHandler struct{ t *KafkaTransport }
+
+func (h *batchConsumerHandler) Setup(sarama.ConsumerGroupSession) error   { return nil }
+func (h *batchConsumerHandler) Cleanup(sarama.ConsumerGroupSession) error { return nil }
+func (h *batchConsumerHandler) ConsumeClaim(sess sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	for msg := range claim.Messages() {
+		var env kafkaBatchEnvelope
+		if err := json.Unmarshal(msg.Value, &env); err != nil {
+			continue
+		}
+		h.t.pendingAcks.Store(env.Batch.JobID, func() { sess.MarkMessage(msg, "") })
+		select {
+		case h.t.batches <- env.Batch:
+		case <-h.t.cancelled:
+			return nil
+		}
+	}
+	return nil
+}
+
+// consumeLoop runs the worker's Sarama consumer group against both the batches and control
+// topics for the lifetime of the process, re-joining after each rebalance the way Sarama expects
+// ConsumerGroup.Consume to be called (in a loop, since it returns when the group rebalances).
+func (t *KafkaTransport) consumeLoop() {
+	ctx := context.Background()
+	handler := &batchConsumerHandler{t: t}
+	go func() {
+		for {
+			if err := t.consumer.Consume(ctx, []string{t.batchesTopic}, handler); err != nil {
+				return
+			}
+			if ctx.Err() != nil {
+				return
+			}
+		}
+	}()
+
+	controlHandler := &controlConsumerHandler{t: t}
+	for {
+		if err := t.consumer.Consume(ctx, []string{t.controlTopic}, controlHandler); err != nil {
+			return
+		}
+		select {
+		case <-t.cancelled:
+			return
+		default:
+		}
+	}
+}
+
+type controlConsumerHandler struct{ t *KafkaTransport }
+
+func (h *controlConsumerHandler) Setup(sarama.ConsumerGroupSession) error   { return nil }
+func (h *controlConsumerHandler) Cleanup(sarama.ConsumerGroupSession) error { return nil }
+func (h *controlConsumerHandler) ConsumeClaim(sess sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	for msg := range claim.Messages() {
+		var env kafkaControlEnvelope
+		if err := json.Unmarshal(msg.Value, &env); err == nil && env.Cancel {
+			h.t.cancelOnce.Do(func() { close(h.t.cancelled) })
+			sess.MarkMessage(msg, "")
+			return nil
+		}
+		sess.MarkMessage(msg, "")
+	}
+	return nil
+}
+
+// PublishBatch implements JobTransport. Coordinator side.
+func (t *KafkaTransport) PublishBatch(ctx context.Context, batch Batch) error {
+	payload, err := json.Marshal(kafkaBatchEnvelope{Batch: batch})
+	if err != nil {
+		return err
+	}
+	_, _, err = t.producer.SendMessage(&sarama.ProducerMessage{
+		Topic: t.batchesTopic,
+		Value: sarama.ByteEncoder(payload),
+	})
+	return err
+}
+
+// ConsumeBatch implements JobTransport. Worker side. ack marks the Kafka offset for this batch's
+// message as processed, giving at-least-once delivery: if the worker dies before calling ack, the
+// batch is redelivered to whichever worker the group rebalances the partition to.
+func (t *KafkaTransport) ConsumeBatch(ctx context.Context) (Batch, func() error, error) {
+	select {
+	case batch, ok := <-t.batches:
+		if !ok {
+			return Batch{}, nil, fmt.Errorf("transport: kafka batch channel closed")
+		}
+		ack := func() error {
+			if fn, ok := t.pendingAcks.LoadAndDelete(batch.JobID); ok {
+				fn.(func())()
+			}
+			return nil
+		}
+		return batch, ack, nil
+	case <-t.cancelled:
+		return Batch{}, nil, fmt.Errorf("transport: cancelled")
+	case <-ctx.Done():
+		return Batch{}, nil, ctx.Err()
+	}
+}
+
+// PublishResult implements JobTransport. Worker side.
+func (t *KafkaTransport) PublishResult(ctx context.Context, res WorkerResult) error {
+	if res.WorkerID == "" {
+		res.WorkerID = t.workerID
+	}
+	payload, err := json.Marshal(res)
+	if err != nil {
+		return err
+	}
+	_, _, err = t.producer.SendMessage(&sarama.ProducerMessage{
+		Topic: t.resultsTopic,
+		Value: sarama.ByteEncoder(payload),
+	})
+	return err
+}
+
+// SubscribeResults implements JobTransport. Coordinator side.
+func (t *KafkaTransport) SubscribeResults(ctx context.Context) (<-chan WorkerResult, error) {
+	out := make(chan WorkerResult)
+	handler := &resultConsumerHandler{out: out}
+	go func() {
+		defer close(out)
+		for {
+			if err := t.resultConsumer.Consume(ctx, []string{t.resultsTopic}, handler); err != nil {
+				return
+			}
+			if ctx.Err() != nil {
+				return
+			}
+		}
+	}()
+	return out, nil
+}
+
+type resultConsumerHandler struct{ out chan<- WorkerResult }
+
+func (h *resultConsumerHandler) Setup(sarama.ConsumerGroupSession) error   { return nil }
+func (h *resultConsumerHandler) Cleanup(sarama.ConsumerGroupSession) error { return nil }
+func (h *resultConsumerHandler) ConsumeClaim(sess sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	for msg := range claim.Messages() {
+		var res WorkerResult
+		if err := json.Unmarshal(msg.Value, &res); err == nil {
+			h.out <- res
+		}
+		sess.MarkMessage(msg, "")
+	}
+	return nil
+}
+
+// PublishCancel implements JobTransport. Coordinator side.
+func (t *KafkaTransport) PublishCancel(ctx context.Context) error {
+	payload, err := json.Marshal(kafkaControlEnvelope{Cancel: true})
+	if err != nil {
+		return err
+	}
+	_, _, err = t.producer.SendMessage(&sarama.ProducerMessage{
+		Topic: t.controlTopic,
+		Value: sarama.ByteEncoder(payload),
+	})
+	return err
+}
+
+// Cancelled implements JobTransport. Worker side.
+func (t *KafkaTransport) Cancelled() <-chan struct{} {
+	return t.cancelled
+}
+
+// Close implements JobTransport.
+func (t *KafkaTransport) Close() error {
+	if t.consumer != nil {
+		t.consumer.Close()
+	}
+	if t.resultConsumer != nil {
+		t.resultConsumer.Close()
+	}
+	t.producer.Close()
+	return t.client.Close()
+}
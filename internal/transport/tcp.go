@@ -0,0 +1,283 @@
+package transport
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+)
+
+// tcpMessageKind tags each newline-delimited JSON frame TCPTransport exchanges over its
+// connections, since a single connection multiplexes batches, acks, results, and the cancel
+// marker rather than using one socket per message type.
+type tcpMessageKind string
+
+const (
+	tcpKindBatch  tcpMessageKind = "batch"
+	tcpKindAck    tcpMessageKind = "ack"
+	tcpKindResult tcpMessageKind = "result"
+	tcpKindCancel tcpMessageKind = "cancel"
+)
+
+type tcpMessage struct {
+	Kind   tcpMessageKind `json:"kind"`
+	Batch  Batch          `json:"batch,omitempty"`
+	Result WorkerResult   `json:"result,omitempty"`
+}
+
+// tcpConn wraps one socket with the line-delimited JSON encoder/decoder both coordinator and
+// worker sides use.
+type tcpConn struct {
+	conn net.Conn
+	enc  *json.Encoder
+	dec  *json.Decoder
+	mu   sync.Mutex // serializes writes; each side only ever has one concurrent reader
+}
+
+func newTCPConn(conn net.Conn) *tcpConn {
+	return &tcpConn{conn: conn, enc: json.NewEncoder(conn), dec: json.NewDecoder(bufio.NewReader(conn))}
+}
+
+func (c *tcpConn) send(msg tcpMessage) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.enc.Encode(msg)
+}
+
+func (c *tcpConn) recv() (tcpMessage, error) {
+	var msg tcpMessage
+	err := c.dec.Decode(&msg)
+	return msg, err
+}
+
+// TCPTransport is JobTransport's dependency-free fallback: a plain length-implicit (newline
+// JSON-delimited) TCP protocol, one persistent connection per worker, instead of a real broker.
+// It's meant for small pools or environments where standing up Kafka isn't worth it; KafkaTransport
+// is the one to reach for at real worker-fleet scale. It does not redeliver: the coordinator reads
+// and ignores acks, so a batch held by a worker that dies mid-batch is not sent again.
+type TCPTransport struct {
+	isCoordinator bool
+
+	// Coordinator-side state.
+	listener net.Listener
+	connsMu  sync.Mutex
+	conns    []*tcpConn
+	pending  chan Batch
+	results  chan WorkerResult
+
+	// Worker-side state.
+	workerConn *tcpConn
+	batches    chan Batch
+	cancelled  chan struct{}
+	cancelOnce sync.Once
+
+	closeOnce sync.Once
+	closeErr  error
+}
+
+// NewTCPCoordinator listens on addr and accepts worker connections as they arrive. prefetch sizes
+// the one pending queue shared by all worker connections: PublishBatch blocks once prefetch*8
+// batches are queued, so a slow verifier backend doesn't make the generator buffer unboundedly.
+func NewTCPCoordinator(addr string, prefetch int) (*TCPTransport, error) {
+	if prefetch <= 0 {
+		prefetch = 1
+	}
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("transport: failed to listen on %s: %w", addr, err)
+	}
+	t := &TCPTransport{
+		isCoordinator: true,
+		listener:      ln,
+		pending:       make(chan Batch, prefetch*8),
+		results:       make(chan WorkerResult, prefetch*8),
+	}
+	go t.acceptLoop()
+	return t, nil
+}
+
+func (t *TCPTransport) acceptLoop() {
+	for {
+		conn, err := t.listener.Accept()
+		if err != nil {
+			return
+		}
+		c := newTCPConn(conn)
+		t.connsMu.Lock()
+		t.conns = append(t.conns, c)
+		t.connsMu.Unlock()
+		go t.serveWorkerConn(c)
+	}
+}
+
+// serveWorkerConn feeds one worker connection from the shared pending queue and forwards every
+// result it sends back onto t.results; acks are read and ignored.
+func (t *TCPTransport) serveWorkerConn(c *tcpConn) {
+	go func() {
+		for batch := range t.pending {
+			if err := c.send(tcpMessage{Kind: tcpKindBatch, Batch: batch}); err != nil {
+				return
+			}
+		}
+	}()
+	for {
+		msg, err := c.recv()
+		if err != nil {
+			return
+		}
+		switch msg.Kind {
+		case tcpKindResult:
+			t.results <- msg.Result
+		case tcpKindAck:
+			// At-least-once delivery only needs the ack to exist so a future retry policy can use
+			// it; with a single delivery attempt per batch there's nothing further to do here.
+		}
+	}
+}
+
+// PublishBatch implements JobTransport. Coordinator side.
+func (t *TCPTransport) PublishBatch(ctx context.Context, batch Batch) error {
+	if !t.isCoordinator {
+		return errors.New("transport: PublishBatch called on a worker-side TCPTransport")
+	}
+	select {
+	case t.pending <- batch:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// SubscribeResults implements JobTransport. Coordinator side.
+func (t *TCPTransport) SubscribeResults(ctx context.Context) (<-chan WorkerResult, error) {
+	if !t.isCoordinator {
+		return nil, errors.New("transport: SubscribeResults called on a worker-side TCPTransport")
+	}
+	out := make(chan WorkerResult)
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case res, ok := <-t.results:
+				if !ok {
+					return
+				}
+				select {
+				case out <- res:
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out, nil
+}
+
+// PublishCancel implements JobTransport: broadcasts a cancel marker to every connected worker.
+// Coordinator side.
+func (t *TCPTransport) PublishCancel(ctx context.Context) error {
+	if !t.isCoordinator {
+		return errors.New("transport: PublishCancel called on a worker-side TCPTransport")
+	}
+	t.connsMu.Lock()
+	defer t.connsMu.Unlock()
+	var firstErr error
+	for _, c := range t.conns {
+		if err := c.send(tcpMessage{Kind: tcpKindCancel}); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// NewTCPWorker dials a coordinator started with NewTCPCoordinator.
+func NewTCPWorker(addr string) (*TCPTransport, error) {
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("transport: failed to dial coordinator at %s: %w", addr, err)
+	}
+	t := &TCPTransport{
+		isCoordinator: false,
+		workerConn:    newTCPConn(conn),
+		batches:       make(chan Batch, 8),
+		cancelled:     make(chan struct{}),
+	}
+	go t.workerReadLoop()
+	return t, nil
+}
+
+func (t *TCPTransport) workerReadLoop() {
+	for {
+		msg, err := t.workerConn.recv()
+		if err != nil {
+			return
+		}
+		switch msg.Kind {
+		case tcpKindBatch:
+			select {
+			case t.batches <- msg.Batch:
+			case <-t.cancelled:
+				return
+			}
+		case tcpKindCancel:
+			t.cancelOnce.Do(func() { close(t.cancelled) })
+			return
+		}
+	}
+}
+
+// ConsumeBatch implements JobTransport. Worker side.
+func (t *TCPTransport) ConsumeBatch(ctx context.Context) (Batch, func() error, error) {
+	if t.isCoordinator {
+		return Batch{}, nil, errors.New("transport: ConsumeBatch called on a coordinator-side TCPTransport")
+	}
+	select {
+	case batch, ok := <-t.batches:
+		if !ok {
+			return Batch{}, nil, errors.New("transport: connection to coordinator closed")
+		}
+		ack := func() error {
+			return t.workerConn.send(tcpMessage{Kind: tcpKindAck, Batch: Batch{JobID: batch.JobID}})
+		}
+		return batch, ack, nil
+	case <-t.cancelled:
+		return Batch{}, nil, errors.New("transport: cancelled")
+	case <-ctx.Done():
+		return Batch{}, nil, ctx.Err()
+	}
+}
+
+// PublishResult implements JobTransport. Worker side.
+func (t *TCPTransport) PublishResult(ctx context.Context, res WorkerResult) error {
+	if t.isCoordinator {
+		return errors.New("transport: PublishResult called on a coordinator-side TCPTransport")
+	}
+	return t.workerConn.send(tcpMessage{Kind: tcpKindResult, Result: res})
+}
+
+// Cancelled implements JobTransport. Worker side.
+func (t *TCPTransport) Cancelled() <-chan struct{} {
+	return t.cancelled
+}
+
+// Close implements JobTransport, releasing the listener (coordinator) or connection (worker).
+func (t *TCPTransport) Close() error {
+	t.closeOnce.Do(func() {
+		if t.isCoordinator {
+			t.closeErr = t.listener.Close()
+			t.connsMu.Lock()
+			for _, c := range t.conns {
+				c.conn.Close()
+			}
+			t.connsMu.Unlock()
+		} else {
+			t.closeErr = t.workerConn.conn.Close()
+		}
+	})
+	return t.closeErr
+}
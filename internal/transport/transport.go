@@ -0,0 +1,53 @@
+// Package transport lets cracker.Runner's single-machine generator/worker pipeline be replaced by
+// a networked one: a coordinator process publishes candidate batches and a pool of worker
+// processes consume them, each running the existing verifier.Worker.BatchVerify against its own
+// copy of the target archive, exactly as Runner's in-process worker goroutines do today.
+package transport
+
+import "context"
+
+// Batch is one unit of work: a monotonically increasing JobID (so a coordinator restart or a
+// worker's own logging can identify which batch a result answers) plus the candidate passwords
+// to try.
+type Batch struct {
+	JobID     uint64
+	Passwords []string
+}
+
+// WorkerResult is what a worker reports back after finishing one Batch: its own stable WorkerID
+// (so Runner can keep a Stats.PerThread-shaped slot per remote worker, same as a local worker
+// goroutine's index), the JobID it was answering, how many passwords it actually tried, and
+// whether one of them matched.
+type WorkerResult struct {
+	WorkerID string
+	JobID    uint64
+	Attempts int
+	Found    bool
+	Password string
+}
+
+// JobTransport abstracts the broker a coordinator and its workers exchange batches and results
+// through, so cracker.Runner can run the same generator/stats-aggregation logic whether the pool
+// is local goroutines or remote worker processes. Implementations: KafkaTransport (Sarama-backed)
+// and TCPTransport (a dependency-free fallback).
+type JobTransport interface {
+	// PublishBatch sends batch to the worker pool. Coordinator side only.
+	PublishBatch(ctx context.Context, batch Batch) error
+	// ConsumeBatch blocks until a batch is available, returning it along with an ack function the
+	// caller must invoke only after it has finished BatchVerify-ing every password in the batch.
+	// KafkaTransport redelivers a batch whose worker dies before acking (at-least-once delivery);
+	// TCPTransport does not redeliver, so such a batch's candidates are not tried. Worker side only.
+	ConsumeBatch(ctx context.Context) (batch Batch, ack func() error, err error)
+	// PublishResult reports one worker's outcome for a batch. Worker side only.
+	PublishResult(ctx context.Context, res WorkerResult) error
+	// SubscribeResults returns a channel of every worker's PublishResult calls, closed when ctx is
+	// done or the transport is closed. Coordinator side only.
+	SubscribeResults(ctx context.Context) (<-chan WorkerResult, error)
+	// PublishCancel broadcasts on the control topic that a password was found, so every worker's
+	// ConsumeBatch loop exits instead of continuing to chew through an already-solved archive.
+	// Coordinator side only.
+	PublishCancel(ctx context.Context) error
+	// Cancelled is closed once PublishCancel's marker has been observed. Worker side only.
+	Cancelled() <-chan struct{}
+	Close() error
+}
@@ -23,6 +23,11 @@ type Config struct {
 	AlphabetLen int
 	MinLen      int
 	MaxLen      int
+
+	// InitialElapsed offsets the elapsed-time/ETA display backward by however much time a resumed
+	// checkpoint already accounted for, so a resumed run's clock keeps counting up instead of
+	// restarting from zero.
+	InitialElapsed time.Duration
 }
 
 type statsMsg cracker.Stats
@@ -86,7 +91,7 @@ func NewModel(cfg Config) model {
 		lastCounts: make([]uint64, cfg.Workers),
 		statsOpen:  true,
 		resultOpen: true,
-		start:      time.Now(),
+		start:      time.Now().Add(-cfg.InitialElapsed),
 		idxWidth:   w,
 	}
 
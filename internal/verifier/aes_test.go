@@ -0,0 +1,102 @@
+package verifier
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	yzip "github.com/yeka/zip"
+)
+
+// buildWinZipAESArchive returns an in-memory ZIP with a single WinZip AES entry of the given
+// strength (yzip.AES128Encryption/AES192Encryption/AES256Encryption) encrypted with password.
+func buildWinZipAESArchive(t *testing.T, password, contents string, method yzip.EncryptionMethod) []byte {
+	t.Helper()
+	buf := new(bytes.Buffer)
+	w := yzip.NewWriter(buf)
+	fw, err := w.Encrypt("entry.txt", password, method)
+	if err != nil {
+		t.Fatalf("Encrypt: %v", err)
+	}
+	if _, err := io.WriteString(fw, contents); err != nil {
+		t.Fatalf("WriteString: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestAESVerifierAcceptsCorrectPassword(t *testing.T) {
+	zipBytes := buildWinZipAESArchive(t, "hunter2", "the quick brown fox", yzip.AES256Encryption)
+
+	info, err := ParseWinZipAESInfoAt(zipBytes, 0)
+	if err != nil {
+		t.Fatalf("ParseWinZipAESInfoAt: %v", err)
+	}
+	if info.Strength != 3 {
+		t.Fatalf("Strength = %d, want 3 (AES-256)", info.Strength)
+	}
+
+	v := NewAESVerifier(info)
+	if !v.Verify([]byte("hunter2")) {
+		t.Fatal("Verify(correct password) = false, want true")
+	}
+}
+
+func TestAESVerifierRejectsWrongPassword(t *testing.T) {
+	zipBytes := buildWinZipAESArchive(t, "hunter2", "the quick brown fox", yzip.AES256Encryption)
+
+	info, err := ParseWinZipAESInfoAt(zipBytes, 0)
+	if err != nil {
+		t.Fatalf("ParseWinZipAESInfoAt: %v", err)
+	}
+
+	v := NewAESVerifier(info)
+	if v.Verify([]byte("wrong-password")) {
+		t.Fatal("Verify(wrong password) = true, want false")
+	}
+}
+
+func TestAESVerifierReuseHMACMatchesFreshDerivation(t *testing.T) {
+	zipBytes := buildWinZipAESArchive(t, "hunter2", "the quick brown fox", yzip.AES128Encryption)
+
+	info, err := ParseWinZipAESInfoAt(zipBytes, 0)
+	if err != nil {
+		t.Fatalf("ParseWinZipAESInfoAt: %v", err)
+	}
+
+	fresh := NewAESVerifier(info)
+	cached := NewAESVerifier(info)
+	cached.ReuseHMAC(true)
+
+	for _, pw := range []string{"hunter2", "wrong-password"} {
+		want := fresh.Verify([]byte(pw))
+		got := cached.Verify([]byte(pw))
+		if got != want {
+			t.Fatalf("Verify(%q) with ReuseHMAC(true) = %v, want %v (matching non-cached result)", pw, got, want)
+		}
+	}
+}
+
+func TestAESSaltAndKeyLen(t *testing.T) {
+	cases := []struct {
+		strength byte
+		saltLen  int
+		keyLen   int
+	}{
+		{1, 8, 16},
+		{2, 12, 24},
+		{3, 16, 32},
+		{0, 0, 0},
+		{4, 0, 0},
+	}
+	for _, c := range cases {
+		if got := aesSaltLen(c.strength); got != c.saltLen {
+			t.Errorf("aesSaltLen(%d) = %d, want %d", c.strength, got, c.saltLen)
+		}
+		if got := aesKeyLen(c.strength); got != c.keyLen {
+			t.Errorf("aesKeyLen(%d) = %d, want %d", c.strength, got, c.keyLen)
+		}
+	}
+}
@@ -11,10 +11,18 @@ import (
 
 // Worker performs batch verification against a specific target entry of a ZIP archive.
 // BatchVerify returns:
-// - matchIdx: index into the provided batch for the first matching password, or -1 if none matched
+// - password: the matching password, if one was found this call
+// - found: whether a match was found
 // - attempts: number of attempts performed (typically len(batch))
+//
+// Backends that pipeline work asynchronously (see vulkanWorker's frame ring) may not have
+// checked every password in batch by the time BatchVerify returns; a match among them surfaces on
+// a later call, or from Flush once the caller stops submitting new batches.
 type Worker interface {
-	BatchVerify(batch []string) (matchIdx int, attempts int)
+	BatchVerify(batch []string) (password string, found bool, attempts int)
+	// Flush blocks until any batches still in flight have been checked, reporting a match if one
+	// was pending. Workers with nothing asynchronous outstanding return immediately.
+	Flush() (password string, found bool)
 	Close()
 }
 
@@ -48,6 +56,13 @@ type cpuVerifier struct{}
 type cpuWorker struct {
 	zipBytes    []byte
 	targetIndex int
+
+	// zcVerifier, when set, lets try reject most wrong passwords via the native ZipCrypto check
+	// byte (see ZipCryptoVerifier) before paying for a full yeka/zip Open+Copy. aesVerifier is the
+	// WinZip AES equivalent, via the password verification value; at most one of the two is ever
+	// set, since a target entry is one or the other.
+	zcVerifier  *ZipCryptoVerifier
+	aesVerifier *AESVerifier
 }
 
 func (v *cpuVerifier) NewWorker(zipBytes []byte) (Worker, error) {
@@ -58,29 +73,50 @@ func (v *cpuVerifier) NewWorker(zipBytes []byte) (Worker, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &cpuWorker{
+	w := &cpuWorker{
 		zipBytes:    zipBytes,
 		targetIndex: target,
-	}, nil
+	}
+	if zcInfo, err := ParseZipCryptoInfoAt(zipBytes, target); err == nil {
+		w.zcVerifier = NewZipCryptoVerifier(zcInfo)
+	} else if aesInfo, err := ParseWinZipAESInfoAt(zipBytes, target); err == nil {
+		w.aesVerifier = NewAESVerifier(aesInfo)
+		w.aesVerifier.ReuseHMAC(true)
+	}
+	return w, nil
 }
 
 func (w *cpuWorker) Close() {}
 
-// BatchVerify tries all passwords in the batch and returns the index of the first match (or -1).
-func (w *cpuWorker) BatchVerify(batch []string) (int, int) {
+// Flush is a no-op: BatchVerify never leaves work outstanding on this backend.
+func (w *cpuWorker) Flush() (string, bool) { return "", false }
+
+// BatchVerify tries all passwords in the batch and returns the first match, if any.
+func (w *cpuWorker) BatchVerify(batch []string) (string, bool, int) {
 	if len(batch) == 0 {
-		return -1, 0
+		return "", false, 0
 	}
 	for i, pw := range batch {
 		if w.try(pw) {
-			return i, i + 1
+			return pw, true, i + 1
 		}
 	}
-	return -1, len(batch)
+	return "", false, len(batch)
 }
 
-// try performs a single password attempt by opening a fresh reader and reading the target entry to EOF.
+// try performs a single password attempt. If the target entry has a native fast-path verifier
+// (traditional ZipCrypto or WinZip AES), that check runs first and rejects most wrong passwords
+// without touching yeka/zip at all; a pass still falls through to a real Open+Copy below, since
+// the fast checks carry a residual false-positive rate (~1/256 for the check byte, ~1/65536 for
+// the AES password-verification value).
 func (w *cpuWorker) try(password string) bool {
+	if w.zcVerifier != nil && !w.zcVerifier.Verify([]byte(password)) {
+		return false
+	}
+	if w.aesVerifier != nil && !w.aesVerifier.Verify([]byte(password)) {
+		return false
+	}
+
 	br := bytes.NewReader(w.zipBytes)
 	zr, err := yzip.NewReader(br, int64(len(w.zipBytes)))
 	if err != nil {
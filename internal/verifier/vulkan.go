@@ -1,20 +1,119 @@
 package verifier
 
 import (
+	"encoding/binary"
 	"errors"
 	"fmt"
-	"io/ioutil"
 	"math"
+	"os"
 	"unsafe"
 
 	vk "github.com/vulkan-go/vulkan"
 )
 
+// ShaderVariant selects which compute shader (and its matching descriptor layout and header
+// struct) a vulkanWorker targets. computePipelineFor caches one vk.Pipeline per variant on the
+// vulkanVerifier, so a device serving workers against different archive entry types doesn't
+// rebuild a shader module it's already built.
+type ShaderVariant int
+
+const (
+	// ZipCryptoClassic targets traditional ZipCrypto entries - ZipCryptoInfo and the check-byte
+	// comparison.
+	ZipCryptoClassic ShaderVariant = iota
+	// ZipAES128 targets WinZip AE-1/AE-2 entries encrypted with AES-128: PBKDF2-HMAC-SHA1 (1000
+	// iterations) key derivation and a password-verification-value comparison in place of
+	// ZipCrypto's check byte, matching WinZipAESInfo/AESVerifier's CPU-side equivalent.
+	ZipAES128
+	// ZipAES256 is ZipAES128's AES-256 counterpart. It also serves the rarer AES-192 case: salt
+	// length and derived key length are runtime inputs (the header SSBO's Strength word), not
+	// compile-time constants, so one kernel covers both strengths.
+	ZipAES256
+)
+
+// String renders a ShaderVariant for log/error messages.
+func (s ShaderVariant) String() string {
+	switch s {
+	case ZipCryptoClassic:
+		return "zipcrypto-classic"
+	case ZipAES128:
+		return "zip-aes128"
+	case ZipAES256:
+		return "zip-aes256"
+	default:
+		return "unknown"
+	}
+}
+
+// shaderVariantInfo names the on-disk SPIR-V module backing a ShaderVariant, plus the bits of its
+// descriptor layout and push constant range that differ from ZipCryptoClassic's.
+//
+// The .spv files themselves are not checked into this repository - they're built from GLSL
+// compute shader sources under shaders/ (not yet written) via glslangValidator or glslc, e.g.
+// `glslangValidator -V shaders/zipcrack.comp -o shaders/zipcrack.spv`. Until that build step and
+// its sources exist, NewVulkan's buildPipeline call fails with a "failed to read shader" error on
+// first use, which Runner.Start and RunWorker already treat as a recoverable backend-selection
+// error: both log a warning and fall back to NewCPU rather than propagating it. Vulkan should
+// therefore still be considered experimental/non-functional, not a working alternative to cpu.
+type shaderVariantInfo struct {
+	spvPath string
+	// aesHeader is true for the AES variants: their descriptor set layout has a fourth SSBO
+	// binding (salt/strength/password-verification-value) that ZipCryptoClassic's layout
+	// doesn't, written once per worker instead of per batch - see vulkanWorker.uploadAESHeader.
+	aesHeader bool
+	// pushConstantsSize is the byte size of the push constant range this variant's pipeline
+	// layout declares; see vulkanWorker.pushConstants.
+	pushConstantsSize uint32
+}
+
+var shaderVariants = map[ShaderVariant]shaderVariantInfo{
+	ZipCryptoClassic: {spvPath: "shaders/zipcrack.spv", pushConstantsSize: pushConstantsSize},
+	ZipAES128:        {spvPath: "shaders/zipcrack_aes128.spv", aesHeader: true, pushConstantsSize: 4},
+	ZipAES256:        {spvPath: "shaders/zipcrack_aes256.spv", aesHeader: true, pushConstantsSize: 4},
+}
+
+// vulkanPipeline bundles the device objects one ShaderVariant needs: the compiled pipeline itself,
+// its layout (carrying the push constant range), the descriptor set layout every frame's
+// descriptor set is allocated against, and the variant's aesHeader/pushConstantsSize shape (copied
+// from shaderVariantInfo so callers don't need a second map lookup).
+type vulkanPipeline struct {
+	pipeline            vk.Pipeline
+	pipelineLayout      vk.PipelineLayout
+	descriptorSetLayout vk.DescriptorSetLayout
+	aesHeader           bool
+	pushConstantsSize   uint32
+}
+
 const (
 	// Maximum password length supported by GPU
 	MaxPasswordLength = 256
 	// Default batch size for GPU processing
 	DefaultGPUBatchSize = 4096
+	// vulkanFrameCount is how many batches a vulkanWorker keeps in flight at once. Each frame owns
+	// its own command buffer, staging buffers, descriptor set, and fence, so BatchVerify can
+	// submit batch K+1 while batch K is still executing on the GPU instead of blocking the whole
+	// queue on every call.
+	vulkanFrameCount = 3
+	// pushConstantsSize is the byte size of the {batchLen, checkByte, headerWord0..2} struct
+	// dispatchCompute pushes to the shader every batch, replacing the old header SSBO.
+	pushConstantsSize = 5 * 4
+	// aesHeaderSize is the byte size of the {strength, passwordVerify, salt0..3} struct written
+	// once per worker into an AES variant's fourth SSBO binding - see uploadAESHeader.
+	aesHeaderSize = 6 * 4
+)
+
+// MemoryStrategy selects how a vulkanWorker's SSBOs are backed by device memory. DeviceLocal
+// avoids paying PCIe-read cost on every dispatch on discrete GPUs at the price of an extra
+// staging copy per batch; HostVisible is the simpler zero-copy path integrated GPUs (whose
+// "device" memory already is host memory) gain nothing from bypassing.
+type MemoryStrategy int
+
+const (
+	// MemoryStrategyAuto picks DeviceLocal for discrete GPUs and HostVisible for everything else,
+	// based on the selected physical device's reported type.
+	MemoryStrategyAuto MemoryStrategy = iota
+	MemoryStrategyHostVisible
+	MemoryStrategyDeviceLocal
 )
 
 // vulkanVerifier implements the Verifier interface using Vulkan compute shaders
@@ -26,38 +125,126 @@ type vulkanVerifier struct {
 	commandPool    vk.CommandPool
 	descriptorPool vk.DescriptorPool
 
-	// Compute pipeline
-	computePipeline     vk.Pipeline
-	pipelineLayout      vk.PipelineLayout
-	descriptorSetLayout vk.DescriptorSetLayout
+	// pipelines caches one vulkanPipeline per ShaderVariant actually built, populated lazily by
+	// computePipelineFor.
+	pipelines map[ShaderVariant]*vulkanPipeline
 
 	// Memory properties
-	memoryProperties vk.PhysicalDeviceMemoryProperties
+	memoryProperties   vk.PhysicalDeviceMemoryProperties
+	physicalDeviceType vk.PhysicalDeviceType
+	memoryStrategy     MemoryStrategy
+
+	// subgroupSize is the device's reported subgroup (warp/wavefront) size, used to pick the
+	// dispatch's workgroup divisor instead of a hardcoded constant. Falls back to
+	// defaultWorkgroupSize when VkPhysicalDeviceSubgroupProperties isn't available.
+	subgroupSize uint32
+
+	// ownsInstance is false when this vulkanVerifier represents one device of a multiVulkanVerifier,
+	// whose instance is shared across devices and destroyed once by the owner instead of here.
+	ownsInstance bool
+
+	// debugReportCallback tracks the optional VK_EXT_debug_report setup requested via
+	// VulkanConfig.EnableValidation; see createInstance/installDebugMessenger.
+	debugReportCallback vk.DebugReportCallback
 }
 
-// vulkanWorker represents a per-goroutine worker that processes batches on the GPU
-type vulkanWorker struct {
-	verifier  *vulkanVerifier
-	zipInfo   *ZipCryptoInfo
-	batchSize int
+// VulkanConfig configures optional diagnostics for NewVulkan. The zero value behaves exactly like
+// the validation-free setup NewVulkan always used.
+type VulkanConfig struct {
+	// Strategy controls whether worker SSBOs live in device-local or host-visible memory; the zero
+	// value is MemoryStrategyAuto.
+	Strategy MemoryStrategy
+	// EnableValidation requests VK_LAYER_KHRONOS_validation and VK_EXT_debug_report when the Vulkan
+	// loader reports them available, installing a debug report callback that forwards into Logger.
+	// (vulkan-go's binding never bound VK_EXT_debug_utils's functions, only a couple of its struct
+	// types, so this uses the older but actually-callable debug_report extension instead.)
+	EnableValidation bool
+	// ReportFlags filters which debug report flags reach Logger. Zero behaves like
+	// warning+error only.
+	ReportFlags vk.DebugReportFlags
+	// Logger receives each debug report message as (severity, message). A nil Logger with
+	// EnableValidation set discards messages rather than panicking.
+	Logger func(severity, message string)
+}
 
-	// Vulkan resources for this worker
+// defaultWorkgroupSize is the workgroup divisor used when a device doesn't report a usable
+// subgroup size (e.g. Vulkan 1.0-only drivers, where VkPhysicalDeviceSubgroupProperties is
+// unavailable).
+const defaultWorkgroupSize = 64
+
+// vulkanFrame holds every GPU resource one in-flight batch needs: its own command buffer,
+// descriptor set, and staging buffers, plus the fence that signals when the GPU has finished with
+// all of them. Giving each frame its own resources is what lets frame N+1 be recorded and
+// submitted while frame N is still executing - reusing a single set of buffers would force every
+// submission to wait for the previous one before it could safely overwrite them.
+type vulkanFrame struct {
+	fence         vk.Fence
 	descriptorSet vk.DescriptorSet
 	commandBuffer vk.CommandBuffer
 
-	// Buffers
 	passwordLengthsBuffer vk.Buffer
 	passwordLengthsMemory vk.DeviceMemory
 	passwordDataBuffer    vk.Buffer
 	passwordDataMemory    vk.DeviceMemory
-	zipHeaderBuffer       vk.Buffer
-	zipHeaderMemory       vk.DeviceMemory
 	resultsBuffer         vk.Buffer
 	resultsMemory         vk.DeviceMemory
+
+	// deviceLocal is true when the buffers above are DEVICE_LOCAL and not directly mappable; in
+	// that case the *Staging buffers below are the ones writeToBuffer/downloadResults actually
+	// touch from the host, and dispatchCompute records vkCmdCopyBuffer + barriers to move data
+	// to/from the device-local buffers the shader reads/writes.
+	deviceLocal                  bool
+	passwordLengthsStagingBuffer vk.Buffer
+	passwordLengthsStagingMemory vk.DeviceMemory
+	passwordDataStagingBuffer    vk.Buffer
+	passwordDataStagingMemory    vk.DeviceMemory
+	resultsStagingBuffer         vk.Buffer
+	resultsStagingMemory         vk.DeviceMemory
+
+	// aesHeaderBuffer/aesHeaderMemory back an AES variant's fourth SSBO binding (salt, strength,
+	// password verification value). Always host-visible and written once by uploadAESHeader at
+	// worker creation instead of per batch, since unlike the password buffers its contents never
+	// change for the life of the worker. Unused (zero value) for ZipCryptoClassic workers.
+	aesHeaderBuffer vk.Buffer
+	aesHeaderMemory vk.DeviceMemory
+
+	// submitted is true from the moment this frame's command buffer is queued until its fence has
+	// been waited on and its results read back.
+	submitted bool
+	// batch is the exact slice of candidates this frame's in-flight (or last-completed) submission
+	// is checking, kept around so a match found once the fence signals can be reported against the
+	// batch it actually belongs to rather than whatever batch BatchVerify was just called with.
+	batch []string
+}
+
+// vulkanWorker represents a per-goroutine worker that processes batches on the GPU
+type vulkanWorker struct {
+	verifier *vulkanVerifier
+	// zipInfo is set for a ZipCryptoClassic worker, aesInfo for a ZipAES128/ZipAES256 worker;
+	// exactly one is non-nil depending on variant.
+	zipInfo   *ZipCryptoInfo
+	aesInfo   *WinZipAESInfo
+	batchSize int
+	// queue is the device queue this worker submits to. Defaults to verifier.queue; multiVulkanVerifier
+	// assigns each worker a distinct queue out of a device's pool so several workers on the same
+	// physical device can have independent submissions in flight.
+	queue vk.Queue
+	// variant and pipeline are the ShaderVariant this worker targets and the (possibly shared,
+	// cache-hit) vulkanPipeline built for it.
+	variant  ShaderVariant
+	pipeline *vulkanPipeline
+
+	frames [vulkanFrameCount]*vulkanFrame
+	next   int
 }
 
-// NewVulkan creates a new Vulkan-based verifier
-func NewVulkan() (Verifier, error) {
+// NewVulkan creates a new Vulkan-based verifier. cfg.Strategy controls whether worker SSBOs live
+// in device-local or host-visible memory (MemoryStrategyAuto decides from the selected device's
+// type); cfg.EnableValidation turns on VK_LAYER_KHRONOS_validation and VK_EXT_debug_report when the
+// loader has them, forwarding messages through cfg.Logger instead of leaving shader/pipeline bugs
+// invisible. The zero VulkanConfig behaves exactly like the validation-free setup NewVulkan always
+// used.
+func NewVulkan(cfg VulkanConfig) (Verifier, error) {
 	// Initialize Vulkan with better error handling
 	if err := vk.SetDefaultGetInstanceProcAddr(); err != nil {
 		return nil, fmt.Errorf("failed to set Vulkan loader: %w", err)
@@ -67,10 +254,10 @@ func NewVulkan() (Verifier, error) {
 		return nil, fmt.Errorf("failed to initialize Vulkan (ensure Vulkan SDK is installed and GPU drivers are up to date): %w", err)
 	}
 
-	v := &vulkanVerifier{}
+	v := &vulkanVerifier{memoryStrategy: cfg.Strategy, ownsInstance: true}
 
 	// Create instance
-	if err := v.createInstance(); err != nil {
+	if err := v.createInstance(cfg); err != nil {
 		return nil, fmt.Errorf("failed to create Vulkan instance: %w", err)
 	}
 
@@ -80,6 +267,14 @@ func NewVulkan() (Verifier, error) {
 		return nil, fmt.Errorf("failed to select physical device: %w", err)
 	}
 
+	if v.memoryStrategy == MemoryStrategyAuto {
+		if v.physicalDeviceType == vk.PhysicalDeviceTypeDiscreteGpu {
+			v.memoryStrategy = MemoryStrategyDeviceLocal
+		} else {
+			v.memoryStrategy = MemoryStrategyHostVisible
+		}
+	}
+
 	// Create logical device
 	if err := v.createDevice(); err != nil {
 		v.cleanup()
@@ -92,8 +287,9 @@ func NewVulkan() (Verifier, error) {
 		return nil, fmt.Errorf("failed to create command pool: %w", err)
 	}
 
-	// Load and create compute pipeline
-	if err := v.createComputePipeline(); err != nil {
+	// Load and cache the default variant's compute pipeline eagerly, so a bad/missing shader fails
+	// NewVulkan immediately instead of on the first NewWorker call.
+	if _, err := v.computePipelineFor(ZipCryptoClassic); err != nil {
 		v.cleanup()
 		return nil, fmt.Errorf("failed to create compute pipeline: %w", err)
 	}
@@ -107,9 +303,62 @@ func NewVulkan() (Verifier, error) {
 	return v, nil
 }
 
+// NewWorker picks the target entry's ShaderVariant automatically (see detectShaderVariant) instead
+// of assuming ZipCryptoClassic, so callers get AES support for free once an archive's smallest
+// encrypted entry turns out to be WinZip AES rather than traditional ZipCrypto.
 func (v *vulkanVerifier) NewWorker(zipBytes []byte) (Worker, error) {
-	// Parse ZIP headers to extract ZipCrypto info
-	zipInfo, err := parseZipHeaders(zipBytes)
+	variant, err := detectShaderVariant(zipBytes)
+	if err != nil {
+		return nil, err
+	}
+	return v.NewWorkerForVariant(zipBytes, variant)
+}
+
+// detectShaderVariant returns the ShaderVariant whose pipeline and header layout match the
+// archive's encryption: ZipCryptoClassic whenever parseZipHeaders finds a traditional PKWARE
+// entry, and otherwise ZipAES128/ZipAES256 (by WinZip AE-x strength) for the entry
+// parseWinZipAESHeaders picks. Entry sizes are not compared across the two kinds, so an archive
+// holding both is treated as ZipCrypto even when a WinZip AES entry is smaller.
+func detectShaderVariant(zipBytes []byte) (ShaderVariant, error) {
+	if _, err := parseZipHeaders(zipBytes); err == nil {
+		return ZipCryptoClassic, nil
+	}
+	aesInfo, err := parseWinZipAESHeaders(zipBytes)
+	if err != nil {
+		return 0, fmt.Errorf("verifier: target entry is neither ZipCrypto nor WinZip AES: %w", err)
+	}
+	if aesInfo.Strength == 1 {
+		return ZipAES128, nil
+	}
+	return ZipAES256, nil
+}
+
+// NewWorkerForVariant is NewWorker generalized to a specific ShaderVariant, for callers that
+// already know which pipeline they want instead of relying on detectShaderVariant's scan.
+func (v *vulkanVerifier) NewWorkerForVariant(zipBytes []byte, variant ShaderVariant) (Worker, error) {
+	return v.newWorkerOnQueue(zipBytes, v.queue, variant)
+}
+
+// newWorkerOnQueue is NewWorkerForVariant with an explicit queue, letting multiVulkanVerifier hand
+// out the individual queues it requested from a device instead of always submitting to v.queue.
+func (v *vulkanVerifier) newWorkerOnQueue(zipBytes []byte, queue vk.Queue, variant ShaderVariant) (*vulkanWorker, error) {
+	pipeline, err := v.computePipelineFor(variant)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare %s pipeline: %w", variant, err)
+	}
+
+	// Parse ZIP headers for variant's expected encryption method. Exactly one of zipInfo/aesInfo
+	// ends up set, matching vulkanWorker's field doc.
+	var zipInfo *ZipCryptoInfo
+	var aesInfo *WinZipAESInfo
+	switch variant {
+	case ZipCryptoClassic:
+		zipInfo, err = parseZipHeaders(zipBytes)
+	case ZipAES128, ZipAES256:
+		aesInfo, err = parseWinZipAESHeaders(zipBytes)
+	default:
+		err = fmt.Errorf("unsupported shader variant %s", variant)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse ZIP headers: %w", err)
 	}
@@ -117,31 +366,45 @@ func (v *vulkanVerifier) NewWorker(zipBytes []byte) (Worker, error) {
 	worker := &vulkanWorker{
 		verifier:  v,
 		zipInfo:   zipInfo,
+		aesInfo:   aesInfo,
 		batchSize: DefaultGPUBatchSize,
+		queue:     queue,
+		variant:   variant,
+		pipeline:  pipeline,
 	}
 
-	// Create buffers and descriptor set for this worker
-	if err := worker.createBuffers(); err != nil {
-		return nil, fmt.Errorf("failed to create worker buffers: %w", err)
-	}
-
-	if err := worker.createDescriptorSet(); err != nil {
-		worker.cleanup()
-		return nil, fmt.Errorf("failed to create descriptor set: %w", err)
-	}
-
-	if err := worker.createCommandBuffer(); err != nil {
-		worker.cleanup()
-		return nil, fmt.Errorf("failed to create command buffer: %w", err)
+	for i := range worker.frames {
+		frame := &vulkanFrame{}
+		if err := worker.createFrameBuffers(frame); err != nil {
+			worker.cleanup()
+			return nil, fmt.Errorf("failed to create worker buffers: %w", err)
+		}
+		if err := worker.createFrameDescriptorSet(frame); err != nil {
+			worker.cleanup()
+			return nil, fmt.Errorf("failed to create descriptor set: %w", err)
+		}
+		if err := worker.createFrameCommandBuffer(frame); err != nil {
+			worker.cleanup()
+			return nil, fmt.Errorf("failed to create command buffer: %w", err)
+		}
+		if err := worker.createFrameFence(frame); err != nil {
+			worker.cleanup()
+			return nil, fmt.Errorf("failed to create fence: %w", err)
+		}
+		worker.frames[i] = frame
 	}
 
 	return worker, nil
 }
 
-// BatchVerify processes a batch of passwords on the GPU
-func (w *vulkanWorker) BatchVerify(batch []string) (int, int) {
+// BatchVerify submits batch for GPU verification on the next frame in the ring, first collecting
+// the result of whatever that frame was previously checking (if anything). Because frames rotate
+// round-robin, the first vulkanFrameCount calls return immediately without waiting on the GPU at
+// all; from then on each call blocks only on the fence of the single frame it's about to reuse,
+// not the whole queue, so the other frames' submissions keep running concurrently.
+func (w *vulkanWorker) BatchVerify(batch []string) (string, bool, int) {
 	if len(batch) == 0 {
-		return -1, 0
+		return "", false, 0
 	}
 
 	// Ensure batch doesn't exceed our allocated size
@@ -151,19 +414,50 @@ func (w *vulkanWorker) BatchVerify(batch []string) (int, int) {
 		batch = batch[:batchLen]
 	}
 
-	// Upload password data to GPU
-	if err := w.uploadPasswordData(batch); err != nil {
-		return -1, len(batch) // Fallback to CPU count
+	frame := w.frames[w.next]
+	w.next = (w.next + 1) % vulkanFrameCount
+
+	password, found := w.collectFrame(frame)
+
+	if err := w.uploadPasswordData(frame, batch); err != nil {
+		return password, found, len(batch)
+	}
+	if err := w.dispatchCompute(frame, batchLen); err != nil {
+		return password, found, len(batch)
 	}
+	frame.submitted = true
+	frame.batch = batch
 
-	// Dispatch compute shader
-	if err := w.dispatchCompute(batchLen); err != nil {
-		return -1, len(batch)
+	return password, found, len(batch)
+}
+
+// Flush waits out every frame still in flight, in the order they were submitted, and reports the
+// first match among them. Call this once no more batches will be submitted (the generator has
+// stopped) to make sure a match sitting in an unflushed frame isn't missed.
+func (w *vulkanWorker) Flush() (string, bool) {
+	for i := 0; i < vulkanFrameCount; i++ {
+		frame := w.frames[(w.next+i)%vulkanFrameCount]
+		if password, found := w.collectFrame(frame); found {
+			return password, true
+		}
 	}
+	return "", false
+}
 
-	// Download and check results
-	matchIndex := w.downloadResults(batchLen)
-	return matchIndex, len(batch)
+// collectFrame waits for frame's fence if a submission is outstanding, reads back its results,
+// and marks it free for reuse. It's a no-op on a frame nothing has been submitted to yet.
+func (w *vulkanWorker) collectFrame(frame *vulkanFrame) (string, bool) {
+	if !frame.submitted {
+		return "", false
+	}
+	vk.WaitForFences(w.verifier.device, 1, []vk.Fence{frame.fence}, vk.True, math.MaxUint64)
+	idx := w.downloadResults(frame, len(frame.batch))
+	vk.ResetFences(w.verifier.device, 1, []vk.Fence{frame.fence})
+	frame.submitted = false
+	if idx >= 0 && idx < len(frame.batch) {
+		return frame.batch[idx], true
+	}
+	return "", false
 }
 
 func (w *vulkanWorker) Close() {
@@ -171,7 +465,7 @@ func (w *vulkanWorker) Close() {
 }
 
 // Vulkan initialization methods
-func (v *vulkanVerifier) createInstance() error {
+func (v *vulkanVerifier) createInstance(cfg VulkanConfig) error {
 	appInfo := &vk.ApplicationInfo{
 		SType:              vk.StructureTypeApplicationInfo,
 		PApplicationName:   "ZipCrack",
@@ -181,20 +475,155 @@ func (v *vulkanVerifier) createInstance() error {
 		ApiVersion:         vk.ApiVersion10,
 	}
 
+	// Validation is opt-in: only request the layer/extension when cfg asks for them, and only
+	// when the loader actually reports them available, so running without the Vulkan SDK's
+	// validation layers installed still works exactly as before.
+	var layerNames, extNames []string
+	if cfg.EnableValidation {
+		if hasInstanceLayer(validationLayerName) {
+			layerNames = append(layerNames, validationLayerName+"\x00")
+		}
+		if hasInstanceExtension(debugReportExtensionName) {
+			extNames = append(extNames, debugReportExtensionName+"\x00")
+		}
+	}
+
 	instanceCreateInfo := &vk.InstanceCreateInfo{
-		SType:            vk.StructureTypeInstanceCreateInfo,
-		PApplicationInfo: appInfo,
+		SType:                   vk.StructureTypeInstanceCreateInfo,
+		PApplicationInfo:        appInfo,
+		EnabledLayerCount:       uint32(len(layerNames)),
+		PpEnabledLayerNames:     layerNames,
+		EnabledExtensionCount:   uint32(len(extNames)),
+		PpEnabledExtensionNames: extNames,
 	}
 
 	var instance vk.Instance
 	if ret := vk.CreateInstance(instanceCreateInfo, nil, &instance); ret != vk.Success {
 		return fmt.Errorf("failed to create instance: %s", ret)
 	}
-
 	v.instance = instance
+
+	if len(extNames) > 0 {
+		v.installDebugMessenger(cfg)
+	}
 	return nil
 }
 
+const (
+	validationLayerName      = "VK_LAYER_KHRONOS_validation"
+	debugReportExtensionName = "VK_EXT_debug_report"
+)
+
+// hasInstanceLayer reports whether the Vulkan loader lists name among the available instance
+// layers.
+func hasInstanceLayer(name string) bool {
+	var count uint32
+	if vk.EnumerateInstanceLayerProperties(&count, nil) != vk.Success || count == 0 {
+		return false
+	}
+	layers := make([]vk.LayerProperties, count)
+	if vk.EnumerateInstanceLayerProperties(&count, layers) != vk.Success {
+		return false
+	}
+	for i := range layers {
+		layers[i].Deref()
+		if vk.ToString(layers[i].LayerName[:]) == name {
+			return true
+		}
+	}
+	return false
+}
+
+// hasInstanceExtension reports whether the Vulkan loader lists name among the available instance
+// extensions.
+func hasInstanceExtension(name string) bool {
+	var count uint32
+	if vk.EnumerateInstanceExtensionProperties("", &count, nil) != vk.Success || count == 0 {
+		return false
+	}
+	exts := make([]vk.ExtensionProperties, count)
+	if vk.EnumerateInstanceExtensionProperties("", &count, exts) != vk.Success {
+		return false
+	}
+	for i := range exts {
+		exts[i].Deref()
+		if vk.ToString(exts[i].ExtensionName[:]) == name {
+			return true
+		}
+	}
+	return false
+}
+
+// installDebugMessenger creates a VK_EXT_debug_report callback forwarding every message at or
+// above cfg.ReportFlags into cfg.Logger. Failure to create it (e.g. the loader couldn't resolve
+// vkCreateDebugReportCallbackEXT) is not fatal - diagnostics are a nice-to-have, not a requirement
+// for cracking to work.
+func (v *vulkanVerifier) installDebugMessenger(cfg VulkanConfig) {
+	flags := cfg.ReportFlags
+	if flags == 0 {
+		flags = vk.DebugReportFlags(vk.DebugReportWarningBit | vk.DebugReportErrorBit)
+	}
+	logger := cfg.Logger
+	if logger == nil {
+		logger = func(string, string) {}
+	}
+
+	createInfo := &vk.DebugReportCallbackCreateInfo{
+		SType: vk.StructureTypeDebugReportCallbackCreateInfo,
+		Flags: flags,
+		PfnCallback: func(msgFlags vk.DebugReportFlags, objectType vk.DebugReportObjectType, object uint64, location uint,
+			messageCode int32, pLayerPrefix string, pMessage string, pUserData unsafe.Pointer) vk.Bool32 {
+			logger(debugSeverityName(vk.DebugReportFlagBits(msgFlags)), pMessage)
+			return vk.Bool32(vk.False)
+		},
+	}
+
+	var callback vk.DebugReportCallback
+	if ret := vk.CreateDebugReportCallback(v.instance, createInfo, nil, &callback); ret == vk.Success {
+		v.debugReportCallback = callback
+	}
+}
+
+// debugSeverityName renders a debug report flag as the short string Logger receives.
+func debugSeverityName(flag vk.DebugReportFlagBits) string {
+	switch {
+	case flag&vk.DebugReportErrorBit != 0:
+		return "error"
+	case flag&vk.DebugReportWarningBit != 0:
+		return "warning"
+	case flag&vk.DebugReportPerformanceWarningBit != 0:
+		return "performance-warning"
+	case flag&vk.DebugReportInformationBit != 0:
+		return "info"
+	default:
+		return "debug"
+	}
+}
+
+// createVulkanInstance creates the shared VkInstance used by both a single-device vulkanVerifier
+// and a multiVulkanVerifier (which creates it once and hands it to every vulkanDevice it selects).
+func createVulkanInstance() (vk.Instance, error) {
+	appInfo := &vk.ApplicationInfo{
+		SType:              vk.StructureTypeApplicationInfo,
+		PApplicationName:   "ZipCrack",
+		ApplicationVersion: vk.MakeVersion(1, 0, 0),
+		PEngineName:        "ZipCrack Engine",
+		EngineVersion:      vk.MakeVersion(1, 0, 0),
+		ApiVersion:         vk.ApiVersion10,
+	}
+
+	instanceCreateInfo := &vk.InstanceCreateInfo{
+		SType:            vk.StructureTypeInstanceCreateInfo,
+		PApplicationInfo: appInfo,
+	}
+
+	var instance vk.Instance
+	if ret := vk.CreateInstance(instanceCreateInfo, nil, &instance); ret != vk.Success {
+		return nil, fmt.Errorf("failed to create instance: %s", ret)
+	}
+	return instance, nil
+}
+
 func (v *vulkanVerifier) selectPhysicalDevice() error {
 	var deviceCount uint32
 	if ret := vk.EnumeratePhysicalDevices(v.instance, &deviceCount, nil); ret != vk.Success {
@@ -227,8 +656,10 @@ func (v *vulkanVerifier) selectPhysicalDevice() error {
 			queueFamily.Deref()
 			if (queueFamily.QueueFlags & vk.QueueFlags(vk.QueueComputeBit)) != 0 {
 				v.physicalDevice = device
+				v.physicalDeviceType = properties.DeviceType
 				vk.GetPhysicalDeviceMemoryProperties(device, &v.memoryProperties)
 				v.memoryProperties.Deref()
+				v.subgroupSize = queryComputeSubgroupSize(device)
 				return nil
 			}
 		}
@@ -237,6 +668,34 @@ func (v *vulkanVerifier) selectPhysicalDevice() error {
 	return errors.New("no suitable device found")
 }
 
+// queryComputeSubgroupSize would read VkPhysicalDeviceSubgroupProperties (core since Vulkan 1.1)
+// via the properties2 query chain, but vulkan-go's binding never bound vkGetPhysicalDeviceProperties2
+// (only the struct types it would need), so there's no way to reach that chain from Go here.
+// Plain GetPhysicalDeviceProperties has no pNext chain to carry subgroup info, so every device
+// just falls back to defaultWorkgroupSize, same as a Vulkan 1.0-only driver would.
+func queryComputeSubgroupSize(device vk.PhysicalDevice) uint32 {
+	return defaultWorkgroupSize
+}
+
+// findComputeQueueFamily returns the index and queue count of the first queue family on device
+// that supports compute. NewVulkanMulti uses both values together to request every compute queue
+// a device exposes instead of the single one selectPhysicalDevice/createDevice settle for.
+func findComputeQueueFamily(device vk.PhysicalDevice) (index uint32, count uint32, ok bool) {
+	var queueFamilyCount uint32
+	vk.GetPhysicalDeviceQueueFamilyProperties(device, &queueFamilyCount, nil)
+
+	queueFamilies := make([]vk.QueueFamilyProperties, queueFamilyCount)
+	vk.GetPhysicalDeviceQueueFamilyProperties(device, &queueFamilyCount, queueFamilies)
+
+	for i, queueFamily := range queueFamilies {
+		queueFamily.Deref()
+		if (queueFamily.QueueFlags & vk.QueueFlags(vk.QueueComputeBit)) != 0 {
+			return uint32(i), queueFamily.QueueCount, true
+		}
+	}
+	return 0, 0, false
+}
+
 func (v *vulkanVerifier) createDevice() error {
 	// Find compute queue family
 	var queueFamilyCount uint32
@@ -319,27 +778,61 @@ func (v *vulkanVerifier) createCommandPool() error {
 	return nil
 }
 
-func (v *vulkanVerifier) createComputePipeline() error {
-	// Load SPIR-V shader
-	shaderCode, err := ioutil.ReadFile("shaders/zipcrack.spv")
+// computePipelineFor returns the cached pipeline/layout/descriptor-set-layout for variant,
+// building and caching it on first use. Caching per variant (rather than per vulkanVerifier, as
+// before ShaderVariant existed) is what lets a device serve workers targeting different archive
+// entry types without rebuilding a shader module every time a new one shows up.
+func (v *vulkanVerifier) computePipelineFor(variant ShaderVariant) (*vulkanPipeline, error) {
+	if p, ok := v.pipelines[variant]; ok {
+		return p, nil
+	}
+
+	info, ok := shaderVariants[variant]
+	if !ok {
+		return nil, fmt.Errorf("verifier: unknown shader variant %s", variant)
+	}
+
+	p, err := v.buildPipeline(info)
+	if err != nil {
+		return nil, err
+	}
+	if v.pipelines == nil {
+		v.pipelines = make(map[ShaderVariant]*vulkanPipeline)
+	}
+	v.pipelines[variant] = p
+	return p, nil
+}
+
+func (v *vulkanVerifier) buildPipeline(info shaderVariantInfo) (*vulkanPipeline, error) {
+	// Shaders are read from a "shaders/" directory relative to the working directory the tool was
+	// launched from, matching every other on-disk asset this CLI expects (wordlists, target ZIPs).
+	shaderCode, err := os.ReadFile(info.spvPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read shader %q: %w", info.spvPath, err)
+	}
+
+	shaderWords, err := spirvToUint32Slice(shaderCode)
 	if err != nil {
-		return fmt.Errorf("failed to read shader file: %w", err)
+		return nil, fmt.Errorf("invalid shader %q: %w", info.spvPath, err)
 	}
 
 	// Create shader module
 	shaderCreateInfo := &vk.ShaderModuleCreateInfo{
 		SType:    vk.StructureTypeShaderModuleCreateInfo,
 		CodeSize: uint(len(shaderCode)),
-		PCode:    reinterpretAsUint32Slice(shaderCode),
+		PCode:    shaderWords,
 	}
 
 	var shaderModule vk.ShaderModule
 	if ret := vk.CreateShaderModule(v.device, shaderCreateInfo, nil, &shaderModule); ret != vk.Success {
-		return fmt.Errorf("failed to create shader module: %s", ret)
+		return nil, fmt.Errorf("failed to create shader module: %s", ret)
 	}
 	defer vk.DestroyShaderModule(v.device, shaderModule, nil)
 
-	// Create descriptor set layout
+	// Create descriptor set layout. The ZIP header used to be a fourth SSBO binding here; it's now
+	// pushed per-dispatch via a push constant range instead (see pushConstants below), since it's
+	// tiny, constant per worker, and re-uploading it through a whole buffer bound in the
+	// descriptor set every batch was wasted round trips.
 	bindings := []vk.DescriptorSetLayoutBinding{
 		{
 			Binding:         0,
@@ -359,12 +852,16 @@ func (v *vulkanVerifier) createComputePipeline() error {
 			DescriptorCount: 1,
 			StageFlags:      vk.ShaderStageFlags(vk.ShaderStageComputeBit),
 		},
-		{
+	}
+	if info.aesHeader {
+		// AES variants carry salt/strength/password-verification-value in a fourth SSBO instead
+		// of the push constant range. See uploadAESHeader.
+		bindings = append(bindings, vk.DescriptorSetLayoutBinding{
 			Binding:         3,
 			DescriptorType:  vk.DescriptorTypeStorageBuffer,
 			DescriptorCount: 1,
 			StageFlags:      vk.ShaderStageFlags(vk.ShaderStageComputeBit),
-		},
+		})
 	}
 
 	layoutCreateInfo := &vk.DescriptorSetLayoutCreateInfo{
@@ -375,22 +872,29 @@ func (v *vulkanVerifier) createComputePipeline() error {
 
 	var descriptorSetLayout vk.DescriptorSetLayout
 	if ret := vk.CreateDescriptorSetLayout(v.device, layoutCreateInfo, nil, &descriptorSetLayout); ret != vk.Success {
-		return fmt.Errorf("failed to create descriptor set layout: %s", ret)
+		return nil, fmt.Errorf("failed to create descriptor set layout: %s", ret)
+	}
+
+	// Create pipeline layout. pushConstants carries {batchLen, checkByte, headerWord0..2} -
+	// everything dispatchCompute used to upload through the now-removed header SSBO.
+	pushConstantRange := vk.PushConstantRange{
+		StageFlags: vk.ShaderStageFlags(vk.ShaderStageComputeBit),
+		Offset:     0,
+		Size:       info.pushConstantsSize,
 	}
-	v.descriptorSetLayout = descriptorSetLayout
 
-	// Create pipeline layout
 	pipelineLayoutCreateInfo := &vk.PipelineLayoutCreateInfo{
-		SType:          vk.StructureTypePipelineLayoutCreateInfo,
-		SetLayoutCount: 1,
-		PSetLayouts:    []vk.DescriptorSetLayout{descriptorSetLayout},
+		SType:                  vk.StructureTypePipelineLayoutCreateInfo,
+		SetLayoutCount:         1,
+		PSetLayouts:            []vk.DescriptorSetLayout{descriptorSetLayout},
+		PushConstantRangeCount: 1,
+		PPushConstantRanges:    []vk.PushConstantRange{pushConstantRange},
 	}
 
 	var pipelineLayout vk.PipelineLayout
 	if ret := vk.CreatePipelineLayout(v.device, pipelineLayoutCreateInfo, nil, &pipelineLayout); ret != vk.Success {
-		return fmt.Errorf("failed to create pipeline layout: %s", ret)
+		return nil, fmt.Errorf("failed to create pipeline layout: %s", ret)
 	}
-	v.pipelineLayout = pipelineLayout
 
 	// Create compute pipeline
 	stageCreateInfo := &vk.PipelineShaderStageCreateInfo{
@@ -408,18 +912,22 @@ func (v *vulkanVerifier) createComputePipeline() error {
 
 	var pipeline vk.Pipeline
 	if ret := vk.CreateComputePipelines(v.device, vk.PipelineCache(vk.NullHandle), 1, []vk.ComputePipelineCreateInfo{*pipelineCreateInfo}, nil, []vk.Pipeline{pipeline}); ret != vk.Success {
-		return fmt.Errorf("failed to create compute pipeline: %s", ret)
-	}
-	v.computePipeline = pipeline
-
-	return nil
+		return nil, fmt.Errorf("failed to create compute pipeline: %s", ret)
+	}
+	return &vulkanPipeline{
+		pipeline:            pipeline,
+		pipelineLayout:      pipelineLayout,
+		descriptorSetLayout: descriptorSetLayout,
+		aesHeader:           info.aesHeader,
+		pushConstantsSize:   info.pushConstantsSize,
+	}, nil
 }
 
 func (v *vulkanVerifier) createDescriptorPool() error {
 	poolSizes := []vk.DescriptorPoolSize{
 		{
 			Type:            vk.DescriptorTypeStorageBuffer,
-			DescriptorCount: 1000, // Allow for many workers
+			DescriptorCount: 4000, // 3-4 storage buffers per frame's set, vulkanFrameCount sets per worker, many workers
 		},
 	}
 
@@ -441,40 +949,76 @@ func (v *vulkanVerifier) createDescriptorPool() error {
 }
 
 // Worker buffer management
-func (w *vulkanWorker) createBuffers() error {
+func (w *vulkanWorker) createFrameBuffers(frame *vulkanFrame) error {
 	// Calculate buffer sizes
 	passwordLengthsSize := uint64(w.batchSize * 4)              // uint32 per password
 	passwordDataSize := uint64(w.batchSize * MaxPasswordLength) // Worst case: max length per password
-	zipHeaderSize := uint64(32)                                 // 12 bytes header + 4 bytes check byte + padding
 	resultsSize := uint64(w.batchSize * 4)                      // uint32 per result
 
-	// Create buffers
-	if err := w.createBuffer(passwordLengthsSize, vk.BufferUsageFlags(vk.BufferUsageStorageBufferBit),
-		vk.MemoryPropertyFlags(vk.MemoryPropertyHostVisibleBit|vk.MemoryPropertyHostCoherentBit),
-		&w.passwordLengthsBuffer, &w.passwordLengthsMemory); err != nil {
-		return fmt.Errorf("failed to create password lengths buffer: %w", err)
-	}
+	frame.deviceLocal = w.verifier.memoryStrategy == MemoryStrategyDeviceLocal
 
-	if err := w.createBuffer(passwordDataSize, vk.BufferUsageFlags(vk.BufferUsageStorageBufferBit),
-		vk.MemoryPropertyFlags(vk.MemoryPropertyHostVisibleBit|vk.MemoryPropertyHostCoherentBit),
-		&w.passwordDataBuffer, &w.passwordDataMemory); err != nil {
-		return fmt.Errorf("failed to create password data buffer: %w", err)
+	ssboUsage := vk.BufferUsageFlags(vk.BufferUsageStorageBufferBit)
+	if frame.deviceLocal {
+		ssboUsage |= vk.BufferUsageFlags(vk.BufferUsageTransferDstBit | vk.BufferUsageTransferSrcBit)
 	}
+	hostVisible := vk.MemoryPropertyFlags(vk.MemoryPropertyHostVisibleBit | vk.MemoryPropertyHostCoherentBit)
 
-	if err := w.createBuffer(zipHeaderSize, vk.BufferUsageFlags(vk.BufferUsageStorageBufferBit),
-		vk.MemoryPropertyFlags(vk.MemoryPropertyHostVisibleBit|vk.MemoryPropertyHostCoherentBit),
-		&w.zipHeaderBuffer, &w.zipHeaderMemory); err != nil {
-		return fmt.Errorf("failed to create zip header buffer: %w", err)
+	// inputs describes the SSBOs the shader only reads (password lengths, password data); results is
+	// handled separately below since the shader writes it instead.
+	type inputBuffer struct {
+		size           uint64
+		buffer         *vk.Buffer
+		memory         *vk.DeviceMemory
+		stagingBuffer  *vk.Buffer
+		stagingMemory  *vk.DeviceMemory
+		errDescription string
+	}
+	inputs := []inputBuffer{
+		{passwordLengthsSize, &frame.passwordLengthsBuffer, &frame.passwordLengthsMemory, &frame.passwordLengthsStagingBuffer, &frame.passwordLengthsStagingMemory, "password lengths"},
+		{passwordDataSize, &frame.passwordDataBuffer, &frame.passwordDataMemory, &frame.passwordDataStagingBuffer, &frame.passwordDataStagingMemory, "password data"},
 	}
 
-	if err := w.createBuffer(resultsSize, vk.BufferUsageFlags(vk.BufferUsageStorageBufferBit),
-		vk.MemoryPropertyFlags(vk.MemoryPropertyHostVisibleBit|vk.MemoryPropertyHostCoherentBit),
-		&w.resultsBuffer, &w.resultsMemory); err != nil {
-		return fmt.Errorf("failed to create results buffer: %w", err)
+	for _, in := range inputs {
+		if frame.deviceLocal {
+			if err := w.createBuffer(in.size, ssboUsage, vk.MemoryPropertyFlags(vk.MemoryPropertyDeviceLocalBit),
+				in.buffer, in.memory); err != nil {
+				return fmt.Errorf("failed to create %s buffer: %w", in.errDescription, err)
+			}
+			if err := w.createBuffer(in.size, vk.BufferUsageFlags(vk.BufferUsageTransferSrcBit), hostVisible,
+				in.stagingBuffer, in.stagingMemory); err != nil {
+				return fmt.Errorf("failed to create %s staging buffer: %w", in.errDescription, err)
+			}
+		} else {
+			if err := w.createBuffer(in.size, ssboUsage, hostVisible, in.buffer, in.memory); err != nil {
+				return fmt.Errorf("failed to create %s buffer: %w", in.errDescription, err)
+			}
+		}
+	}
+	if frame.deviceLocal {
+		if err := w.createBuffer(resultsSize, ssboUsage, vk.MemoryPropertyFlags(vk.MemoryPropertyDeviceLocalBit),
+			&frame.resultsBuffer, &frame.resultsMemory); err != nil {
+			return fmt.Errorf("failed to create results buffer: %w", err)
+		}
+		if err := w.createBuffer(resultsSize, vk.BufferUsageFlags(vk.BufferUsageTransferDstBit), hostVisible,
+			&frame.resultsStagingBuffer, &frame.resultsStagingMemory); err != nil {
+			return fmt.Errorf("failed to create results staging buffer: %w", err)
+		}
+	} else {
+		if err := w.createBuffer(resultsSize, ssboUsage, hostVisible, &frame.resultsBuffer, &frame.resultsMemory); err != nil {
+			return fmt.Errorf("failed to create results buffer: %w", err)
+		}
+	}
+	if w.pipeline.aesHeader {
+		if err := w.createBuffer(aesHeaderSize, vk.BufferUsageFlags(vk.BufferUsageStorageBufferBit), hostVisible,
+			&frame.aesHeaderBuffer, &frame.aesHeaderMemory); err != nil {
+			return fmt.Errorf("failed to create AES header buffer: %w", err)
+		}
+		if err := w.uploadAESHeader(frame); err != nil {
+			return fmt.Errorf("failed to upload AES header: %w", err)
+		}
 	}
 
-	// Upload ZIP header data (constant for this worker)
-	return w.uploadZipHeader()
+	return nil
 }
 
 func (w *vulkanWorker) createBuffer(size uint64, usage vk.BufferUsageFlags, properties vk.MemoryPropertyFlags, buffer *vk.Buffer, memory *vk.DeviceMemory) error {
@@ -525,27 +1069,53 @@ func (w *vulkanWorker) findMemoryType(typeFilter uint32, properties vk.MemoryPro
 }
 
 // Helper methods
-func (w *vulkanWorker) uploadZipHeader() error {
-	headerData := struct {
-		EncryptedHeader [3]uint32
-		CheckByte       uint32
-		Padding         [3]uint32
-	}{
-		CheckByte: uint32(w.zipInfo.CheckByte),
-	}
 
-	// Pack 12-byte header into 3 uint32s
+// pushConstants packs this batch's dispatch parameters for w.variant's pipeline layout.
+// ZipCryptoClassic carries {batchLen, checkByte, headerWord0..2}; the AES variants only need
+// batchLen, since salt/strength/password-verification-value are constant per worker and live in
+// the header SSBO (frame.aesHeaderBuffer, see uploadAESHeader) instead of being re-pushed every
+// batch. The returned slice's length always matches w.pipeline.pushConstantsSize/4.
+func (w *vulkanWorker) pushConstants(batchLen int) []uint32 {
+	if w.variant != ZipCryptoClassic {
+		return []uint32{uint32(batchLen)}
+	}
+	pc := make([]uint32, 5)
+	pc[0] = uint32(batchLen)
+	pc[1] = uint32(w.zipInfo.CheckByte)
 	for i := 0; i < 12; i += 4 {
-		headerData.EncryptedHeader[i/4] = uint32(w.zipInfo.EncryptedHeader[i]) |
+		pc[2+i/4] = uint32(w.zipInfo.EncryptedHeader[i]) |
 			uint32(w.zipInfo.EncryptedHeader[i+1])<<8 |
 			uint32(w.zipInfo.EncryptedHeader[i+2])<<16 |
 			uint32(w.zipInfo.EncryptedHeader[i+3])<<24
 	}
+	return pc
+}
 
-	return w.writeToBuffer(w.zipHeaderMemory, unsafe.Pointer(&headerData), unsafe.Sizeof(headerData))
+// uploadAESHeader writes this worker's AES salt, strength, and password verification value into
+// frame's header SSBO. Unlike the password buffers, this is written once at worker creation
+// instead of per batch: salt and the verification value are fixed for the whole target entry, not
+// the candidate password.
+func (w *vulkanWorker) uploadAESHeader(frame *vulkanFrame) error {
+	var header [6]uint32
+	header[0] = uint32(w.aesInfo.Strength)
+	header[1] = uint32(w.aesInfo.PasswordVerify[0]) | uint32(w.aesInfo.PasswordVerify[1])<<8
+	for i, b := range w.aesInfo.Salt {
+		header[2+i/4] |= uint32(b) << ((i % 4) * 8)
+	}
+	return w.writeToBuffer(frame.aesHeaderMemory, unsafe.Pointer(&header[0]), uintptr(len(header)*4))
 }
 
-func (w *vulkanWorker) uploadPasswordData(batch []string) error {
+// uploadMemory returns whichever of a frame's direct/staging memory handles the host should
+// actually write to: the staging one when the real buffer is device-local and unmappable, the
+// direct one otherwise.
+func (w *vulkanWorker) uploadMemory(direct, staging vk.DeviceMemory, deviceLocal bool) vk.DeviceMemory {
+	if deviceLocal {
+		return staging
+	}
+	return direct
+}
+
+func (w *vulkanWorker) uploadPasswordData(frame *vulkanFrame, batch []string) error {
 	// Upload password lengths
 	lengths := make([]uint32, w.batchSize)
 	for i, password := range batch {
@@ -555,7 +1125,8 @@ func (w *vulkanWorker) uploadPasswordData(batch []string) error {
 		lengths[i] = uint32(len(password))
 	}
 
-	if err := w.writeToBuffer(w.passwordLengthsMemory, unsafe.Pointer(&lengths[0]), uintptr(len(lengths)*4)); err != nil {
+	lengthsMemory := w.uploadMemory(frame.passwordLengthsMemory, frame.passwordLengthsStagingMemory, frame.deviceLocal)
+	if err := w.writeToBuffer(lengthsMemory, unsafe.Pointer(&lengths[0]), uintptr(len(lengths)*4)); err != nil {
 		return err
 	}
 
@@ -581,7 +1152,8 @@ func (w *vulkanWorker) uploadPasswordData(batch []string) error {
 		}
 	}
 
-	return w.writeToBuffer(w.passwordDataMemory, unsafe.Pointer(&passwordData[0]), uintptr(len(passwordData)*4))
+	dataMemory := w.uploadMemory(frame.passwordDataMemory, frame.passwordDataStagingMemory, frame.deviceLocal)
+	return w.writeToBuffer(dataMemory, unsafe.Pointer(&passwordData[0]), uintptr(len(passwordData)*4))
 }
 
 func (w *vulkanWorker) writeToBuffer(memory vk.DeviceMemory, data unsafe.Pointer, size uintptr) error {
@@ -597,81 +1169,83 @@ func (w *vulkanWorker) writeToBuffer(memory vk.DeviceMemory, data unsafe.Pointer
 	return nil
 }
 
-func (w *vulkanWorker) createDescriptorSet() error {
+func (w *vulkanWorker) createFrameDescriptorSet(frame *vulkanFrame) error {
 	allocInfo := &vk.DescriptorSetAllocateInfo{
 		SType:              vk.StructureTypeDescriptorSetAllocateInfo,
 		DescriptorPool:     w.verifier.descriptorPool,
 		DescriptorSetCount: 1,
-		PSetLayouts:        []vk.DescriptorSetLayout{w.verifier.descriptorSetLayout},
+		PSetLayouts:        []vk.DescriptorSetLayout{w.pipeline.descriptorSetLayout},
 	}
 
 	var descriptorSet vk.DescriptorSet
 	if ret := vk.AllocateDescriptorSets(w.verifier.device, allocInfo, &descriptorSet); ret != vk.Success {
 		return fmt.Errorf("failed to allocate descriptor sets: %s", ret)
 	}
-	w.descriptorSet = descriptorSet
+	frame.descriptorSet = descriptorSet
 
 	// Update descriptor sets
 	descriptorWrites := []vk.WriteDescriptorSet{
 		{
 			SType:           vk.StructureTypeWriteDescriptorSet,
-			DstSet:          w.descriptorSet,
+			DstSet:          frame.descriptorSet,
 			DstBinding:      0,
 			DstArrayElement: 0,
 			DescriptorType:  vk.DescriptorTypeStorageBuffer,
 			DescriptorCount: 1,
 			PBufferInfo: []vk.DescriptorBufferInfo{{
-				Buffer: w.passwordLengthsBuffer,
+				Buffer: frame.passwordLengthsBuffer,
 				Offset: 0,
 				Range:  vk.DeviceSize(vk.WholeSize),
 			}},
 		},
 		{
 			SType:           vk.StructureTypeWriteDescriptorSet,
-			DstSet:          w.descriptorSet,
+			DstSet:          frame.descriptorSet,
 			DstBinding:      1,
 			DstArrayElement: 0,
 			DescriptorType:  vk.DescriptorTypeStorageBuffer,
 			DescriptorCount: 1,
 			PBufferInfo: []vk.DescriptorBufferInfo{{
-				Buffer: w.passwordDataBuffer,
+				Buffer: frame.passwordDataBuffer,
 				Offset: 0,
 				Range:  vk.DeviceSize(vk.WholeSize),
 			}},
 		},
 		{
 			SType:           vk.StructureTypeWriteDescriptorSet,
-			DstSet:          w.descriptorSet,
+			DstSet:          frame.descriptorSet,
 			DstBinding:      2,
 			DstArrayElement: 0,
 			DescriptorType:  vk.DescriptorTypeStorageBuffer,
 			DescriptorCount: 1,
 			PBufferInfo: []vk.DescriptorBufferInfo{{
-				Buffer: w.zipHeaderBuffer,
+				Buffer: frame.resultsBuffer,
 				Offset: 0,
 				Range:  vk.DeviceSize(vk.WholeSize),
 			}},
 		},
-		{
+	}
+	if w.pipeline.aesHeader {
+		descriptorWrites = append(descriptorWrites, vk.WriteDescriptorSet{
 			SType:           vk.StructureTypeWriteDescriptorSet,
-			DstSet:          w.descriptorSet,
+			DstSet:          frame.descriptorSet,
 			DstBinding:      3,
 			DstArrayElement: 0,
 			DescriptorType:  vk.DescriptorTypeStorageBuffer,
 			DescriptorCount: 1,
 			PBufferInfo: []vk.DescriptorBufferInfo{{
-				Buffer: w.resultsBuffer,
+				Buffer: frame.aesHeaderBuffer,
 				Offset: 0,
 				Range:  vk.DeviceSize(vk.WholeSize),
 			}},
-		},
+		})
 	}
 
 	vk.UpdateDescriptorSets(w.verifier.device, uint32(len(descriptorWrites)), descriptorWrites, 0, nil)
 	return nil
 }
 
-func (w *vulkanWorker) createCommandBuffer() error {
+func (w *vulkanWorker) createFrameCommandBuffer(frame *vulkanFrame) error {
 	allocInfo := &vk.CommandBufferAllocateInfo{
 		SType:              vk.StructureTypeCommandBufferAllocateInfo,
 		CommandPool:        w.verifier.commandPool,
@@ -683,58 +1257,126 @@ func (w *vulkanWorker) createCommandBuffer() error {
 	if ret := vk.AllocateCommandBuffers(w.verifier.device, allocInfo, commandBuffers); ret != vk.Success {
 		return fmt.Errorf("failed to allocate command buffer: %s", ret)
 	}
-	w.commandBuffer = commandBuffers[0]
+	frame.commandBuffer = commandBuffers[0]
+
+	return nil
+}
+
+func (w *vulkanWorker) createFrameFence(frame *vulkanFrame) error {
+	// Created unsignaled: collectFrame only waits on it once a submission has actually used it.
+	fenceCreateInfo := &vk.FenceCreateInfo{
+		SType: vk.StructureTypeFenceCreateInfo,
+	}
 
+	var fence vk.Fence
+	if ret := vk.CreateFence(w.verifier.device, fenceCreateInfo, nil, &fence); ret != vk.Success {
+		return fmt.Errorf("failed to create fence: %s", ret)
+	}
+	frame.fence = fence
 	return nil
 }
 
-func (w *vulkanWorker) dispatchCompute(batchSize int) error {
+func (w *vulkanWorker) dispatchCompute(frame *vulkanFrame, batchSize int) error {
 	beginInfo := &vk.CommandBufferBeginInfo{
 		SType: vk.StructureTypeCommandBufferBeginInfo,
 		Flags: vk.CommandBufferUsageFlags(vk.CommandBufferUsageOneTimeSubmitBit),
 	}
 
-	if ret := vk.BeginCommandBuffer(w.commandBuffer, beginInfo); ret != vk.Success {
+	if ret := vk.BeginCommandBuffer(frame.commandBuffer, beginInfo); ret != vk.Success {
 		return fmt.Errorf("failed to begin command buffer: %s", ret)
 	}
 
-	vk.CmdBindPipeline(w.commandBuffer, vk.PipelineBindPointCompute, w.verifier.computePipeline)
-	vk.CmdBindDescriptorSets(w.commandBuffer, vk.PipelineBindPointCompute, w.verifier.pipelineLayout, 0, 1, []vk.DescriptorSet{w.descriptorSet}, 0, nil)
+	if frame.deviceLocal {
+		copies := []struct {
+			src, dst vk.Buffer
+			size     uint64
+		}{
+			{frame.passwordLengthsStagingBuffer, frame.passwordLengthsBuffer, uint64(w.batchSize * 4)},
+			{frame.passwordDataStagingBuffer, frame.passwordDataBuffer, uint64(w.batchSize * MaxPasswordLength)},
+		}
+		for _, c := range copies {
+			region := vk.BufferCopy{Size: vk.DeviceSize(c.size)}
+			vk.CmdCopyBuffer(frame.commandBuffer, c.src, c.dst, 1, []vk.BufferCopy{region})
+		}
+
+		// Transfer writes must complete and be visible to the shader before it reads them.
+		inputBarrier := vk.MemoryBarrier{
+			SType:         vk.StructureTypeMemoryBarrier,
+			SrcAccessMask: vk.AccessFlags(vk.AccessTransferWriteBit),
+			DstAccessMask: vk.AccessFlags(vk.AccessShaderReadBit),
+		}
+		vk.CmdPipelineBarrier(frame.commandBuffer,
+			vk.PipelineStageFlags(vk.PipelineStageTransferBit), vk.PipelineStageFlags(vk.PipelineStageComputeShaderBit),
+			0, 1, []vk.MemoryBarrier{inputBarrier}, 0, nil, 0, nil)
+	}
+
+	vk.CmdBindPipeline(frame.commandBuffer, vk.PipelineBindPointCompute, w.pipeline.pipeline)
+	vk.CmdBindDescriptorSets(frame.commandBuffer, vk.PipelineBindPointCompute, w.pipeline.pipelineLayout, 0, 1, []vk.DescriptorSet{frame.descriptorSet}, 0, nil)
+
+	pc := w.pushConstants(batchSize)
+	vk.CmdPushConstants(frame.commandBuffer, w.pipeline.pipelineLayout, vk.ShaderStageFlags(vk.ShaderStageComputeBit), 0, w.pipeline.pushConstantsSize, unsafe.Pointer(&pc[0]))
+
+	// Dispatch with workgroups of w.verifier.subgroupSize invocations. queryComputeSubgroupSize
+	// does not query the device and always returns defaultWorkgroupSize, so every device gets
+	// the same workgroup size.
+	workgroupSize := w.verifier.subgroupSize
+	if workgroupSize == 0 {
+		workgroupSize = defaultWorkgroupSize
+	}
+	groupCount := uint32((uint32(batchSize) + workgroupSize - 1) / workgroupSize)
+	vk.CmdDispatch(frame.commandBuffer, groupCount, 1, 1)
+
+	if frame.deviceLocal {
+		// The shader's writes must complete and be visible to the transfer stage before we copy
+		// the results back out to host-visible staging.
+		resultsBarrier := vk.MemoryBarrier{
+			SType:         vk.StructureTypeMemoryBarrier,
+			SrcAccessMask: vk.AccessFlags(vk.AccessShaderWriteBit),
+			DstAccessMask: vk.AccessFlags(vk.AccessTransferReadBit),
+		}
+		vk.CmdPipelineBarrier(frame.commandBuffer,
+			vk.PipelineStageFlags(vk.PipelineStageComputeShaderBit), vk.PipelineStageFlags(vk.PipelineStageTransferBit),
+			0, 1, []vk.MemoryBarrier{resultsBarrier}, 0, nil, 0, nil)
 
-	// Dispatch with workgroups of 64 threads each
-	groupCount := uint32((batchSize + 63) / 64)
-	vk.CmdDispatch(w.commandBuffer, groupCount, 1, 1)
+		region := vk.BufferCopy{Size: vk.DeviceSize(w.batchSize * 4)}
+		vk.CmdCopyBuffer(frame.commandBuffer, frame.resultsBuffer, frame.resultsStagingBuffer, 1, []vk.BufferCopy{region})
+	}
 
-	if ret := vk.EndCommandBuffer(w.commandBuffer); ret != vk.Success {
+	if ret := vk.EndCommandBuffer(frame.commandBuffer); ret != vk.Success {
 		return fmt.Errorf("failed to end command buffer: %s", ret)
 	}
 
-	// Submit command buffer
+	// Submit command buffer, signaling this frame's fence on completion instead of blocking the
+	// whole queue - collectFrame (called on this frame's next reuse, or from Flush) is what waits
+	// on it, so the other vulkanFrameCount-1 frames' submissions can run concurrently in the
+	// meantime.
 	submitInfo := &vk.SubmitInfo{
 		SType:              vk.StructureTypeSubmitInfo,
 		CommandBufferCount: 1,
-		PCommandBuffers:    []vk.CommandBuffer{w.commandBuffer},
+		PCommandBuffers:    []vk.CommandBuffer{frame.commandBuffer},
 	}
 
-	if ret := vk.QueueSubmit(w.verifier.queue, 1, []vk.SubmitInfo{*submitInfo}, vk.NullFence); ret != vk.Success {
+	if ret := vk.QueueSubmit(w.queue, 1, []vk.SubmitInfo{*submitInfo}, frame.fence); ret != vk.Success {
 		return fmt.Errorf("failed to submit command buffer: %s", ret)
 	}
 
-	// Wait for completion
-	if ret := vk.QueueWaitIdle(w.verifier.queue); ret != vk.Success {
-		return fmt.Errorf("failed to wait for queue: %s", ret)
-	}
-
 	return nil
 }
 
-func (w *vulkanWorker) downloadResults(batchSize int) int {
+func (w *vulkanWorker) downloadResults(frame *vulkanFrame, batchSize int) int {
+	// Device-local results were already copied to host-visible staging at the end of
+	// dispatchCompute's command buffer; read from there instead of the unmappable device buffer.
+	resultsMemory := frame.resultsMemory
+	if frame.deviceLocal {
+		resultsMemory = frame.resultsStagingMemory
+	}
+
 	// Map results buffer and read results
 	var mappedData unsafe.Pointer
-	if ret := vk.MapMemory(w.verifier.device, w.resultsMemory, 0, vk.DeviceSize(vk.WholeSize), 0, &mappedData); ret != vk.Success {
+	if ret := vk.MapMemory(w.verifier.device, resultsMemory, 0, vk.DeviceSize(vk.WholeSize), 0, &mappedData); ret != vk.Success {
 		return -1
 	}
-	defer vk.UnmapMemory(w.verifier.device, w.resultsMemory)
+	defer vk.UnmapMemory(w.verifier.device, resultsMemory)
 
 	results := (*[4096]uint32)(mappedData)[:batchSize]
 
@@ -749,36 +1391,58 @@ func (w *vulkanWorker) downloadResults(batchSize int) int {
 }
 
 func (w *vulkanWorker) cleanup() {
-	if w.passwordLengthsBuffer != vk.Buffer(vk.NullHandle) {
-		vk.DestroyBuffer(w.verifier.device, w.passwordLengthsBuffer, nil)
-	}
-	if w.passwordLengthsMemory != vk.DeviceMemory(vk.NullHandle) {
-		vk.FreeMemory(w.verifier.device, w.passwordLengthsMemory, nil)
-	}
-	if w.passwordDataBuffer != vk.Buffer(vk.NullHandle) {
-		vk.DestroyBuffer(w.verifier.device, w.passwordDataBuffer, nil)
-	}
-	if w.passwordDataMemory != vk.DeviceMemory(vk.NullHandle) {
-		vk.FreeMemory(w.verifier.device, w.passwordDataMemory, nil)
-	}
-	if w.zipHeaderBuffer != vk.Buffer(vk.NullHandle) {
-		vk.DestroyBuffer(w.verifier.device, w.zipHeaderBuffer, nil)
-	}
-	if w.zipHeaderMemory != vk.DeviceMemory(vk.NullHandle) {
-		vk.FreeMemory(w.verifier.device, w.zipHeaderMemory, nil)
-	}
-	if w.resultsBuffer != vk.Buffer(vk.NullHandle) {
-		vk.DestroyBuffer(w.verifier.device, w.resultsBuffer, nil)
-	}
-	if w.resultsMemory != vk.DeviceMemory(vk.NullHandle) {
-		vk.FreeMemory(w.verifier.device, w.resultsMemory, nil)
-	}
-	if w.descriptorSet != vk.DescriptorSet(vk.NullHandle) {
-		vk.FreeDescriptorSets(w.verifier.device, w.verifier.descriptorPool, 1, &w.descriptorSet)
-	}
-	if w.commandBuffer != vk.CommandBuffer(vk.NullHandle) {
-		commandBuffers := []vk.CommandBuffer{w.commandBuffer}
-		vk.FreeCommandBuffers(w.verifier.device, w.verifier.commandPool, 1, commandBuffers)
+	for _, frame := range w.frames {
+		if frame == nil {
+			continue
+		}
+		// Make sure the GPU is done with this frame's buffers before freeing them out from under it.
+		if frame.submitted {
+			vk.WaitForFences(w.verifier.device, 1, []vk.Fence{frame.fence}, vk.True, math.MaxUint64)
+		}
+		if frame.fence != vk.Fence(vk.NullHandle) {
+			vk.DestroyFence(w.verifier.device, frame.fence, nil)
+		}
+		if frame.passwordLengthsBuffer != vk.Buffer(vk.NullHandle) {
+			vk.DestroyBuffer(w.verifier.device, frame.passwordLengthsBuffer, nil)
+		}
+		if frame.passwordLengthsMemory != vk.DeviceMemory(vk.NullHandle) {
+			vk.FreeMemory(w.verifier.device, frame.passwordLengthsMemory, nil)
+		}
+		if frame.passwordDataBuffer != vk.Buffer(vk.NullHandle) {
+			vk.DestroyBuffer(w.verifier.device, frame.passwordDataBuffer, nil)
+		}
+		if frame.passwordDataMemory != vk.DeviceMemory(vk.NullHandle) {
+			vk.FreeMemory(w.verifier.device, frame.passwordDataMemory, nil)
+		}
+		if frame.resultsBuffer != vk.Buffer(vk.NullHandle) {
+			vk.DestroyBuffer(w.verifier.device, frame.resultsBuffer, nil)
+		}
+		if frame.resultsMemory != vk.DeviceMemory(vk.NullHandle) {
+			vk.FreeMemory(w.verifier.device, frame.resultsMemory, nil)
+		}
+		if frame.aesHeaderBuffer != vk.Buffer(vk.NullHandle) {
+			vk.DestroyBuffer(w.verifier.device, frame.aesHeaderBuffer, nil)
+		}
+		if frame.aesHeaderMemory != vk.DeviceMemory(vk.NullHandle) {
+			vk.FreeMemory(w.verifier.device, frame.aesHeaderMemory, nil)
+		}
+		for _, b := range []vk.Buffer{frame.passwordLengthsStagingBuffer, frame.passwordDataStagingBuffer, frame.resultsStagingBuffer} {
+			if b != vk.Buffer(vk.NullHandle) {
+				vk.DestroyBuffer(w.verifier.device, b, nil)
+			}
+		}
+		for _, m := range []vk.DeviceMemory{frame.passwordLengthsStagingMemory, frame.passwordDataStagingMemory, frame.resultsStagingMemory} {
+			if m != vk.DeviceMemory(vk.NullHandle) {
+				vk.FreeMemory(w.verifier.device, m, nil)
+			}
+		}
+		if frame.descriptorSet != vk.DescriptorSet(vk.NullHandle) {
+			vk.FreeDescriptorSets(w.verifier.device, w.verifier.descriptorPool, 1, &frame.descriptorSet)
+		}
+		if frame.commandBuffer != vk.CommandBuffer(vk.NullHandle) {
+			commandBuffers := []vk.CommandBuffer{frame.commandBuffer}
+			vk.FreeCommandBuffers(w.verifier.device, w.verifier.commandPool, 1, commandBuffers)
+		}
 	}
 }
 
@@ -786,14 +1450,16 @@ func (v *vulkanVerifier) cleanup() {
 	if v.descriptorPool != vk.DescriptorPool(vk.NullHandle) {
 		vk.DestroyDescriptorPool(v.device, v.descriptorPool, nil)
 	}
-	if v.computePipeline != vk.Pipeline(vk.NullHandle) {
-		vk.DestroyPipeline(v.device, v.computePipeline, nil)
-	}
-	if v.pipelineLayout != vk.PipelineLayout(vk.NullHandle) {
-		vk.DestroyPipelineLayout(v.device, v.pipelineLayout, nil)
-	}
-	if v.descriptorSetLayout != vk.DescriptorSetLayout(vk.NullHandle) {
-		vk.DestroyDescriptorSetLayout(v.device, v.descriptorSetLayout, nil)
+	for _, p := range v.pipelines {
+		if p.pipeline != vk.Pipeline(vk.NullHandle) {
+			vk.DestroyPipeline(v.device, p.pipeline, nil)
+		}
+		if p.pipelineLayout != vk.PipelineLayout(vk.NullHandle) {
+			vk.DestroyPipelineLayout(v.device, p.pipelineLayout, nil)
+		}
+		if p.descriptorSetLayout != vk.DescriptorSetLayout(vk.NullHandle) {
+			vk.DestroyDescriptorSetLayout(v.device, p.descriptorSetLayout, nil)
+		}
 	}
 	if v.commandPool != vk.CommandPool(vk.NullHandle) {
 		vk.DestroyCommandPool(v.device, v.commandPool, nil)
@@ -801,18 +1467,41 @@ func (v *vulkanVerifier) cleanup() {
 	if v.device != vk.Device(vk.NullHandle) {
 		vk.DestroyDevice(v.device, nil)
 	}
-	if v.instance != vk.Instance(vk.NullHandle) {
+	if v.debugReportCallback != vk.DebugReportCallback(vk.NullHandle) {
+		vk.DestroyDebugReportCallback(v.instance, v.debugReportCallback, nil)
+	}
+	if v.ownsInstance && v.instance != vk.Instance(vk.NullHandle) {
 		vk.DestroyInstance(v.instance, nil)
 	}
 }
 
-// Helper function to reinterpret byte slice as uint32 slice for SPIR-V
-func reinterpretAsUint32Slice(data []byte) []uint32 {
+// spirvMagicNumber is SPIR-V's required first word (little-endian on disk), per the spec's binary
+// module layout. Checking it catches a mismatched/corrupt shader asset here instead of
+// vk.CreateShaderModule failing later with a much less specific driver error.
+const spirvMagicNumber = 0x07230203
+
+// spirvToUint32Slice converts SPIR-V bytecode bytes into the []uint32 words vk.ShaderModuleCreateInfo.PCode
+// expects. It allocates a fresh slice and copies via binary.LittleEndian rather than reinterpreting
+// data's backing array through an unsafe pointer cast, so it can't alias or mutate the caller's
+// slice, doesn't require data be 4-byte aligned, and doesn't panic on empty input. It also
+// validates the SPIR-V magic word so a malformed shader is reported as an error instead of being
+// silently fed to the driver.
+func spirvToUint32Slice(data []byte) ([]uint32, error) {
+	if len(data) < 4 {
+		return nil, fmt.Errorf("spirv: shader code too short (%d bytes)", len(data))
+	}
+	n := (len(data) + 3) / 4
+	words := make([]uint32, n)
+	padded := data
 	if len(data)%4 != 0 {
-		// Pad to 4-byte boundary
-		padding := 4 - (len(data) % 4)
-		data = append(data, make([]byte, padding)...)
+		padded = make([]byte, n*4)
+		copy(padded, data)
 	}
-
-	return (*[1 << 30]uint32)(unsafe.Pointer(&data[0]))[:len(data)/4]
+	for i := 0; i < n; i++ {
+		words[i] = binary.LittleEndian.Uint32(padded[i*4 : i*4+4])
+	}
+	if words[0] != spirvMagicNumber {
+		return nil, fmt.Errorf("spirv: invalid magic number %#08x (expected %#08x)", words[0], spirvMagicNumber)
+	}
+	return words, nil
 }
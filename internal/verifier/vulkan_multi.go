@@ -0,0 +1,262 @@
+package verifier
+
+import (
+	"errors"
+	"fmt"
+
+	vk "github.com/vulkan-go/vulkan"
+)
+
+// DeviceSelector filters which physical devices NewVulkanMulti considers eligible. The zero value
+// accepts every compute-capable device.
+type DeviceSelector struct {
+	// DiscreteOnly restricts selection to discrete GPUs, skipping integrated/virtual/CPU devices.
+	DiscreteOnly bool
+	// MinVRAMBytes skips devices whose largest DEVICE_LOCAL memory heap is smaller than this.
+	MinVRAMBytes uint64
+	// DenyDeviceIDs skips devices whose VkPhysicalDeviceProperties.DeviceID appears in this list,
+	// for excluding a known-problematic card without disabling its whole vendor/driver.
+	DenyDeviceIDs []uint32
+}
+
+// accepts reports whether a physical device passes every configured filter.
+func (s DeviceSelector) accepts(properties vk.PhysicalDeviceProperties, memProps vk.PhysicalDeviceMemoryProperties) bool {
+	if s.DiscreteOnly && properties.DeviceType != vk.PhysicalDeviceTypeDiscreteGpu {
+		return false
+	}
+	for _, id := range s.DenyDeviceIDs {
+		if id == properties.DeviceID {
+			return false
+		}
+	}
+	if s.MinVRAMBytes > 0 && deviceLocalHeapSize(memProps) < s.MinVRAMBytes {
+		return false
+	}
+	return true
+}
+
+// deviceLocalHeapSize returns the size of the largest DEVICE_LOCAL memory heap a device reports,
+// used as a stand-in for VRAM size since Vulkan has no direct "VRAM size" query.
+func deviceLocalHeapSize(memProps vk.PhysicalDeviceMemoryProperties) uint64 {
+	var largest uint64
+	for i := uint32(0); i < memProps.MemoryHeapCount; i++ {
+		heap := memProps.MemoryHeaps[i]
+		heap.Deref()
+		if heap.Flags&vk.MemoryHeapFlags(vk.MemoryHeapDeviceLocalBit) != 0 && uint64(heap.Size) > largest {
+			largest = uint64(heap.Size)
+		}
+	}
+	return largest
+}
+
+// MultiVulkanOptions configures NewVulkanMulti.
+type MultiVulkanOptions struct {
+	// Strategy is passed through to every selected device's vulkanVerifier, same meaning as NewVulkan's.
+	Strategy MemoryStrategy
+	// Selector filters which enumerated physical devices are used.
+	Selector DeviceSelector
+}
+
+// vulkanDevice is one physical device selected by NewVulkanMulti: its own logical device, compute
+// pipeline, and descriptor pool (via an embedded vulkanVerifier, same as the single-device path),
+// plus every compute queue it exposed.
+type vulkanDevice struct {
+	verifier *vulkanVerifier
+	queues   []vk.Queue
+	// weight estimates this device's relative throughput, from maxComputeWorkGroupInvocations *
+	// subgroupSize. Exposed via multiVulkanVerifier.DeviceWeights so a higher-level dispatcher can
+	// size batches per worker proportionally instead of handing every worker the same
+	// DefaultGPUBatchSize.
+	weight float64
+}
+
+// vulkanWorkerSlot is one (device, queue) pair NewWorker can hand out to a new vulkanWorker.
+type vulkanWorkerSlot struct {
+	device *vulkanDevice
+	queue  vk.Queue
+}
+
+// multiVulkanVerifier implements Verifier by round-robining NewWorker calls across every
+// (device, queue) pair selected from the system's Vulkan-capable GPUs, instead of NewVulkan's
+// single device and single queue.
+type multiVulkanVerifier struct {
+	instance vk.Instance
+	devices  []*vulkanDevice
+	slots    []vulkanWorkerSlot
+	next     int
+}
+
+// NewVulkanMulti enumerates every Vulkan-capable physical device, keeps the ones opts.Selector
+// accepts, and creates one logical device per survivor requesting as many compute queues as the
+// device's queue family reports (queueFamilyProperties.queueCount). NewWorker then round-robins
+// across every (device, queue) pair, so a run can spread workers across multiple GPUs or across
+// several compute queues exposed by a single server-class card.
+func NewVulkanMulti(opts MultiVulkanOptions) (Verifier, error) {
+	if err := vk.SetDefaultGetInstanceProcAddr(); err != nil {
+		return nil, fmt.Errorf("failed to set Vulkan loader: %w", err)
+	}
+	if err := vk.Init(); err != nil {
+		return nil, fmt.Errorf("failed to initialize Vulkan (ensure Vulkan SDK is installed and GPU drivers are up to date): %w", err)
+	}
+
+	instance, err := createVulkanInstance()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Vulkan instance: %w", err)
+	}
+
+	mv := &multiVulkanVerifier{instance: instance}
+
+	var deviceCount uint32
+	if ret := vk.EnumeratePhysicalDevices(instance, &deviceCount, nil); ret != vk.Success {
+		mv.cleanup()
+		return nil, fmt.Errorf("failed to enumerate devices: %s", ret)
+	}
+	if deviceCount == 0 {
+		mv.cleanup()
+		return nil, errors.New("no Vulkan devices found")
+	}
+
+	physicalDevices := make([]vk.PhysicalDevice, deviceCount)
+	if ret := vk.EnumeratePhysicalDevices(instance, &deviceCount, physicalDevices); ret != vk.Success {
+		mv.cleanup()
+		return nil, fmt.Errorf("failed to get devices: %s", ret)
+	}
+
+	for _, pd := range physicalDevices {
+		var properties vk.PhysicalDeviceProperties
+		vk.GetPhysicalDeviceProperties(pd, &properties)
+		properties.Deref()
+
+		queueFamilyIndex, queueCount, ok := findComputeQueueFamily(pd)
+		if !ok {
+			continue
+		}
+
+		var memProps vk.PhysicalDeviceMemoryProperties
+		vk.GetPhysicalDeviceMemoryProperties(pd, &memProps)
+		memProps.Deref()
+
+		if !opts.Selector.accepts(properties, memProps) {
+			continue
+		}
+
+		dev, err := newVulkanDevice(instance, pd, properties, memProps, queueFamilyIndex, queueCount, opts.Strategy)
+		if err != nil {
+			mv.cleanup()
+			return nil, fmt.Errorf("failed to initialize device %d: %w", properties.DeviceID, err)
+		}
+		mv.devices = append(mv.devices, dev)
+		for _, q := range dev.queues {
+			mv.slots = append(mv.slots, vulkanWorkerSlot{device: dev, queue: q})
+		}
+	}
+
+	if len(mv.devices) == 0 {
+		mv.cleanup()
+		return nil, errors.New("no suitable device found")
+	}
+
+	return mv, nil
+}
+
+// newVulkanDevice builds the vulkanVerifier-backed state (logical device, pipeline, descriptor
+// pool) for one selected physical device, requesting every compute queue its queue family reports.
+func newVulkanDevice(instance vk.Instance, pd vk.PhysicalDevice, properties vk.PhysicalDeviceProperties, memProps vk.PhysicalDeviceMemoryProperties, queueFamilyIndex, queueCount uint32, strategy MemoryStrategy) (*vulkanDevice, error) {
+	v := &vulkanVerifier{
+		instance:           instance,
+		physicalDevice:     pd,
+		physicalDeviceType: properties.DeviceType,
+		memoryProperties:   memProps,
+		memoryStrategy:     strategy,
+		subgroupSize:       queryComputeSubgroupSize(pd),
+		ownsInstance:       false,
+	}
+	if v.memoryStrategy == MemoryStrategyAuto {
+		if v.physicalDeviceType == vk.PhysicalDeviceTypeDiscreteGpu {
+			v.memoryStrategy = MemoryStrategyDeviceLocal
+		} else {
+			v.memoryStrategy = MemoryStrategyHostVisible
+		}
+	}
+
+	priorities := make([]float32, queueCount)
+	for i := range priorities {
+		priorities[i] = 1.0
+	}
+	queueCreateInfo := &vk.DeviceQueueCreateInfo{
+		SType:            vk.StructureTypeDeviceQueueCreateInfo,
+		QueueFamilyIndex: queueFamilyIndex,
+		QueueCount:       queueCount,
+		PQueuePriorities: priorities,
+	}
+	deviceCreateInfo := &vk.DeviceCreateInfo{
+		SType:                vk.StructureTypeDeviceCreateInfo,
+		QueueCreateInfoCount: 1,
+		PQueueCreateInfos:    []vk.DeviceQueueCreateInfo{*queueCreateInfo},
+	}
+
+	var device vk.Device
+	if ret := vk.CreateDevice(pd, deviceCreateInfo, nil, &device); ret != vk.Success {
+		return nil, fmt.Errorf("failed to create device: %s", ret)
+	}
+	v.device = device
+
+	queues := make([]vk.Queue, queueCount)
+	for i := range queues {
+		vk.GetDeviceQueue(device, queueFamilyIndex, uint32(i), &queues[i])
+	}
+	v.queue = queues[0]
+
+	if err := v.createCommandPool(); err != nil {
+		return nil, fmt.Errorf("failed to create command pool: %w", err)
+	}
+	if _, err := v.computePipelineFor(ZipCryptoClassic); err != nil {
+		return nil, fmt.Errorf("failed to create compute pipeline: %w", err)
+	}
+	if err := v.createDescriptorPool(); err != nil {
+		return nil, fmt.Errorf("failed to create descriptor pool: %w", err)
+	}
+
+	limits := properties.Limits
+	limits.Deref()
+	weight := float64(limits.MaxComputeWorkGroupInvocations) * float64(v.subgroupSize)
+
+	return &vulkanDevice{verifier: v, queues: queues, weight: weight}, nil
+}
+
+// NewWorker hands out the next (device, queue) pair in round-robin order, auto-detecting the
+// target entry's ShaderVariant the same way the single-device vulkanVerifier.NewWorker does.
+func (mv *multiVulkanVerifier) NewWorker(zipBytes []byte) (Worker, error) {
+	if len(mv.slots) == 0 {
+		return nil, errors.New("no Vulkan devices available")
+	}
+	variant, err := detectShaderVariant(zipBytes)
+	if err != nil {
+		return nil, err
+	}
+	slot := mv.slots[mv.next%len(mv.slots)]
+	mv.next++
+	return slot.device.verifier.newWorkerOnQueue(zipBytes, slot.queue, variant)
+}
+
+// DeviceWeights returns each selected device's estimated relative throughput, in the same order
+// devices were selected in. A higher-level batch-size dispatcher can use these to hand faster
+// devices proportionally larger batches instead of a flat DefaultGPUBatchSize per worker.
+func (mv *multiVulkanVerifier) DeviceWeights() []float64 {
+	weights := make([]float64, len(mv.devices))
+	for i, d := range mv.devices {
+		weights[i] = d.weight
+	}
+	return weights
+}
+
+func (mv *multiVulkanVerifier) cleanup() {
+	for _, d := range mv.devices {
+		if d.verifier != nil {
+			d.verifier.cleanup()
+		}
+	}
+	if mv.instance != vk.Instance(vk.NullHandle) {
+		vk.DestroyInstance(mv.instance, nil)
+	}
+}
@@ -0,0 +1,213 @@
+package verifier
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"zipcrack/internal/candidates"
+)
+
+// DefaultCalibrationTarget is the kernel runtime VulkanPool's calibration phase aims for. Letting a
+// compute dispatch run much longer than this risks a driver TDR on Windows, and a shared target
+// lets heterogeneous GPUs (an integrated GPU sharing the pool with a discrete one) converge on
+// batch sizes proportional to their own throughput instead of all using DefaultGPUBatchSize.
+const DefaultCalibrationTarget = 100 * time.Millisecond
+
+// PoolConfig configures a VulkanPool.
+type PoolConfig struct {
+	// Strategy and Selector are passed through to NewVulkanMulti unchanged.
+	Strategy MemoryStrategy
+	Selector DeviceSelector
+	// CalibrationTarget is the per-dispatch kernel runtime calibration aims for. Defaults to
+	// DefaultCalibrationTarget.
+	CalibrationTarget time.Duration
+}
+
+// DeviceProgress reports one pool device's calibrated batch size and measured throughput, for
+// callers that want to display per-device progress on a multi-GPU run.
+type DeviceProgress struct {
+	DeviceIndex      int
+	BatchSize        int
+	Attempts         uint64
+	CandidatesPerSec float64
+}
+
+// poolDevice is one (device, queue) slot's worker plus the running totals Progress reports.
+type poolDevice struct {
+	index     int
+	worker    Worker
+	batchSize int32  // atomic; set by calibrate, read by Run
+	attempts  uint64 // atomic
+	nanos     uint64 // atomic; cumulative BatchVerify wall time, for throughput reporting
+}
+
+// VulkanPool distributes candidate batches across every (device, queue) pair NewVulkanMulti
+// selects via a work-stealing loop - each device pulls its next batch as soon as it finishes the
+// last one, rather than waiting on a batch assigned to it up front - so a slow iGPU sharing the
+// pool with a fast dGPU doesn't stall the dGPU's queue. Call calibrate before Run to size each
+// device's batch from its own measured throughput instead of a single flat DefaultGPUBatchSize.
+type VulkanPool struct {
+	mv      *multiVulkanVerifier
+	cfg     PoolConfig
+	devices []*poolDevice
+}
+
+// NewVulkanPool selects every eligible Vulkan device via NewVulkanMulti, creates one worker per
+// (device, queue) pair for zipBytes' target entry, and calibrates each worker's batch size.
+func NewVulkanPool(cfg PoolConfig, zipBytes []byte) (*VulkanPool, error) {
+	if cfg.CalibrationTarget <= 0 {
+		cfg.CalibrationTarget = DefaultCalibrationTarget
+	}
+	v, err := NewVulkanMulti(MultiVulkanOptions{Strategy: cfg.Strategy, Selector: cfg.Selector})
+	if err != nil {
+		return nil, err
+	}
+	mv := v.(*multiVulkanVerifier)
+
+	p := &VulkanPool{mv: mv, cfg: cfg}
+	for i := range mv.slots {
+		w, err := mv.NewWorker(zipBytes)
+		if err != nil {
+			p.Close()
+			return nil, fmt.Errorf("failed to create worker for device %d: %w", i, err)
+		}
+		p.devices = append(p.devices, &poolDevice{index: i, worker: w, batchSize: DefaultGPUBatchSize})
+	}
+
+	p.calibrate()
+	return p, nil
+}
+
+// calibrate times one BatchVerify call per device at increasing batch sizes, doubling from
+// DefaultGPUBatchSize until the measured duration reaches cfg.CalibrationTarget (capped at
+// 1<<24 candidates so a very fast device doesn't grow its batch unboundedly), then extrapolates a
+// final size from the last measurement's candidates/sec so the real run starts near the target
+// instead of at whatever power-of-two the probe loop happened to stop on.
+func (p *VulkanPool) calibrate() {
+	const maxProbe = 1 << 24
+	for _, d := range p.devices {
+		size := DefaultGPUBatchSize
+		var elapsed time.Duration
+		for {
+			probe := make([]string, size)
+			for i := range probe {
+				probe[i] = "calibration-probe"
+			}
+			start := time.Now()
+			d.worker.BatchVerify(probe)
+			elapsed = time.Since(start)
+			if elapsed >= p.cfg.CalibrationTarget || size >= maxProbe {
+				break
+			}
+			size *= 2
+		}
+		if elapsed > 0 {
+			perCandidate := elapsed.Seconds() / float64(size)
+			size = int(p.cfg.CalibrationTarget.Seconds() / perCandidate)
+			if size < 1 {
+				size = 1
+			}
+		}
+		atomic.StoreInt32(&d.batchSize, int32(size))
+	}
+}
+
+// Run pulls batches from gen and verifies them across every device until gen is exhausted, a
+// device finds the password, or ctx is cancelled. gen is not safe for concurrent use on its own;
+// Run serializes NextBatch calls behind a mutex so devices can still verify their batches in
+// parallel once they have one.
+func (p *VulkanPool) Run(ctx context.Context, gen candidates.Generator) (password string, found bool) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	var once sync.Once
+	resultCh := make(chan string, 1)
+
+	for _, d := range p.devices {
+		wg.Add(1)
+		go func(d *poolDevice) {
+			defer wg.Done()
+			for ctx.Err() == nil {
+				mu.Lock()
+				batch, done := gen.NextBatch(nil, int(atomic.LoadInt32(&d.batchSize)))
+				mu.Unlock()
+				// A worker may accept fewer than len(batch) passwords per call, so keep submitting
+				// the remainder.
+				for off := 0; off < len(batch) && ctx.Err() == nil; {
+					start := time.Now()
+					pw, okFound, attempts := d.worker.BatchVerify(batch[off:])
+					atomic.AddUint64(&d.nanos, uint64(time.Since(start).Nanoseconds()))
+					atomic.AddUint64(&d.attempts, uint64(attempts))
+					if okFound {
+						once.Do(func() {
+							password, found = pw, true
+							resultCh <- pw
+						})
+						cancel()
+						return
+					}
+					if attempts <= 0 {
+						break
+					}
+					off += attempts
+				}
+				if done {
+					// Drain frames still in flight once gen has nothing more to submit.
+					if pw, okFound := d.worker.Flush(); okFound {
+						once.Do(func() {
+							password, found = pw, true
+							resultCh <- pw
+						})
+						cancel()
+					}
+					return
+				}
+			}
+		}(d)
+	}
+
+	go func() {
+		wg.Wait()
+		close(resultCh)
+	}()
+	<-resultCh
+	return password, found
+}
+
+// Progress reports every device's calibrated batch size and measured throughput so far, in
+// device-index order.
+func (p *VulkanPool) Progress() []DeviceProgress {
+	out := make([]DeviceProgress, len(p.devices))
+	for i, d := range p.devices {
+		attempts := atomic.LoadUint64(&d.attempts)
+		nanos := atomic.LoadUint64(&d.nanos)
+		var rate float64
+		if nanos > 0 {
+			rate = float64(attempts) / (float64(nanos) / 1e9)
+		}
+		out[i] = DeviceProgress{
+			DeviceIndex:      d.index,
+			BatchSize:        int(atomic.LoadInt32(&d.batchSize)),
+			Attempts:         attempts,
+			CandidatesPerSec: rate,
+		}
+	}
+	return out
+}
+
+// Close releases every device's worker and the underlying multiVulkanVerifier's Vulkan resources.
+func (p *VulkanPool) Close() {
+	for _, d := range p.devices {
+		if d.worker != nil {
+			d.worker.Close()
+		}
+	}
+	if p.mv != nil {
+		p.mv.cleanup()
+	}
+}
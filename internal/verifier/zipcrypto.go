@@ -0,0 +1,47 @@
+package verifier
+
+import "hash/crc32"
+
+// ZipCryptoVerifier runs the traditional PKWARE ("ZipCrypto") stream cipher in-process to check
+// a candidate password against a single entry's 12-byte encryption header. This replaces the
+// much slower path of opening the entry through yeka/zip for every candidate: the three 32-bit
+// keys and the keystream derivation below are exactly what yeka/zip does internally, minus the
+// reader setup, inflate plumbing, and allocation overhead.
+type ZipCryptoVerifier struct {
+	info *ZipCryptoInfo
+}
+
+// NewZipCryptoVerifier builds a verifier bound to a single entry's ZipCrypto metadata.
+func NewZipCryptoVerifier(info *ZipCryptoInfo) *ZipCryptoVerifier {
+	return &ZipCryptoVerifier{info: info}
+}
+
+// Verify initializes the ZipCrypto key schedule with password, decrypts the 12-byte encryption
+// header, and reports whether the final decrypted byte matches the entry's expected CheckByte.
+// A true result still carries a ~1/256 false-positive rate inherent to the check-byte method;
+// callers that need certainty should confirm survivors against the real compressed stream.
+func (v *ZipCryptoVerifier) Verify(password []byte) bool {
+	key0 := uint32(0x12345678)
+	key1 := uint32(0x23456789)
+	key2 := uint32(0x34567890)
+
+	updateKeys := func(b byte) {
+		key0 = crc32.IEEETable[byte(key0)^b] ^ (key0 >> 8)
+		key1 = (key1+(key0&0xFF))*0x08088405 + 1
+		key2 = crc32.IEEETable[byte(key2)^byte(key1>>24)] ^ (key2 >> 8)
+	}
+
+	for _, b := range password {
+		updateKeys(b)
+	}
+
+	var decrypted byte
+	for _, c := range v.info.EncryptedHeader {
+		temp := key2 | 3
+		keystream := byte((uint32(temp) * uint32(temp^1)) >> 8)
+		decrypted = c ^ keystream
+		updateKeys(decrypted)
+	}
+
+	return decrypted == v.info.CheckByte
+}
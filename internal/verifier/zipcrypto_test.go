@@ -0,0 +1,76 @@
+package verifier
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	yzip "github.com/yeka/zip"
+)
+
+// buildZipCryptoArchive returns an in-memory ZIP with a single traditional-ZipCrypto entry
+// encrypted with password, so tests can exercise ParseZipCryptoInfoAt/ZipCryptoVerifier against
+// real yeka/zip output instead of hand-rolled header bytes.
+func buildZipCryptoArchive(t *testing.T, password, contents string) []byte {
+	t.Helper()
+	buf := new(bytes.Buffer)
+	w := yzip.NewWriter(buf)
+	fw, err := w.Encrypt("entry.txt", password, yzip.StandardEncryption)
+	if err != nil {
+		t.Fatalf("Encrypt: %v", err)
+	}
+	if _, err := io.WriteString(fw, contents); err != nil {
+		t.Fatalf("WriteString: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestZipCryptoVerifierAcceptsCorrectPassword(t *testing.T) {
+	zipBytes := buildZipCryptoArchive(t, "hunter2", "the quick brown fox")
+
+	info, err := ParseZipCryptoInfoAt(zipBytes, 0)
+	if err != nil {
+		t.Fatalf("ParseZipCryptoInfoAt: %v", err)
+	}
+
+	v := NewZipCryptoVerifier(info)
+	if !v.Verify([]byte("hunter2")) {
+		t.Fatal("Verify(correct password) = false, want true")
+	}
+}
+
+func TestZipCryptoVerifierRejectsWrongPassword(t *testing.T) {
+	zipBytes := buildZipCryptoArchive(t, "hunter2", "the quick brown fox")
+
+	info, err := ParseZipCryptoInfoAt(zipBytes, 0)
+	if err != nil {
+		t.Fatalf("ParseZipCryptoInfoAt: %v", err)
+	}
+
+	v := NewZipCryptoVerifier(info)
+	if v.Verify([]byte("wrong-password")) {
+		t.Fatal("Verify(wrong password) = true, want false")
+	}
+}
+
+func TestParseZipCryptoInfoAtRejectsAESEntry(t *testing.T) {
+	buf := new(bytes.Buffer)
+	w := yzip.NewWriter(buf)
+	fw, err := w.Encrypt("entry.txt", "hunter2", yzip.AES256Encryption)
+	if err != nil {
+		t.Fatalf("Encrypt: %v", err)
+	}
+	if _, err := io.WriteString(fw, "contents"); err != nil {
+		t.Fatalf("WriteString: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	if _, err := ParseZipCryptoInfoAt(buf.Bytes(), 0); err == nil {
+		t.Fatal("ParseZipCryptoInfoAt on an AES entry = nil error, want an error")
+	}
+}
@@ -19,6 +19,19 @@ type ZipCryptoInfo struct {
 	CheckByte byte
 }
 
+// WinZipAESInfo holds the metadata needed to verify a password against a WinZip AE-1/AE-2
+// encrypted entry (compression method 99, extra field tag 0x9901): the PBKDF2 salt, the 2-byte
+// password verification value that immediately follows it, and the AES strength, which
+// determines both the salt length and the derived key size.
+type WinZipAESInfo struct {
+	// Strength is 1 (AES-128), 2 (AES-192), or 3 (AES-256).
+	Strength byte
+	// Salt is the PBKDF2 salt: 8/12/16 bytes for AES-128/192/256 respectively.
+	Salt []byte
+	// PasswordVerify is the 2-byte value immediately following Salt in the entry's file data.
+	PasswordVerify [2]byte
+}
+
 // parseZipHeaders scans the ZIP file and returns ZipCryptoInfo for the smallest encrypted entry
 func parseZipHeaders(zipBytes []byte) (*ZipCryptoInfo, error) {
 	if len(zipBytes) < 22 {
@@ -86,6 +99,296 @@ func parseZipHeaders(zipBytes []byte) (*ZipCryptoInfo, error) {
 	return bestInfo, nil
 }
 
+// ParseZipCryptoInfoAt extracts ZipCryptoInfo for the central directory entry at ordinal
+// position targetIndex (matching the indexing of archive/zip-style File slices). It returns an
+// error if that entry isn't traditional ZipCrypto (encryption flag set, not WinZip AES), which
+// callers use to detect AES or unencrypted entries and fall back accordingly. The compression
+// method is irrelevant to the check-byte decryption itself, so entries are accepted regardless of
+// method (Deflate/method 8 is the common case for real-world ZipCrypto zips; Store/method 0 is
+// just as valid) as long as they aren't AES (method 99).
+func ParseZipCryptoInfoAt(zipBytes []byte, targetIndex int) (*ZipCryptoInfo, error) {
+	if len(zipBytes) < 22 {
+		return nil, errors.New("zip file too small")
+	}
+
+	eocdOffset := findEOCD(zipBytes)
+	if eocdOffset == -1 {
+		return nil, errors.New("end of central directory not found")
+	}
+
+	cdOffset := binary.LittleEndian.Uint32(zipBytes[eocdOffset+16:])
+	numEntries := binary.LittleEndian.Uint16(zipBytes[eocdOffset+10:])
+
+	if cdOffset >= uint32(len(zipBytes)) {
+		return nil, errors.New("invalid central directory offset")
+	}
+
+	offset := cdOffset
+	for i := uint16(0); i < numEntries && offset < uint32(len(zipBytes)-46); i++ {
+		if binary.LittleEndian.Uint32(zipBytes[offset:]) != 0x02014b50 {
+			return nil, errors.New("invalid central directory entry")
+		}
+
+		flag := binary.LittleEndian.Uint16(zipBytes[offset+8:])
+		method := binary.LittleEndian.Uint16(zipBytes[offset+10:])
+		modTime := binary.LittleEndian.Uint16(zipBytes[offset+12:])
+		crc32 := binary.LittleEndian.Uint32(zipBytes[offset+16:])
+		fileNameLen := binary.LittleEndian.Uint16(zipBytes[offset+28:])
+		extraLen := binary.LittleEndian.Uint16(zipBytes[offset+30:])
+		commentLen := binary.LittleEndian.Uint16(zipBytes[offset+32:])
+		localHeaderOffset := binary.LittleEndian.Uint32(zipBytes[offset+42:])
+
+		nextOffset := offset + 46 + uint32(fileNameLen) + uint32(extraLen) + uint32(commentLen)
+
+		if int(i) == targetIndex {
+			if (flag&0x01) == 0 || method == 99 {
+				return nil, errors.New("target entry is not traditional ZipCrypto")
+			}
+			if localHeaderOffset >= uint32(len(zipBytes)-30) {
+				return nil, errors.New("invalid local header offset")
+			}
+			return extractZipCryptoInfo(zipBytes, localHeaderOffset, flag, crc32, modTime)
+		}
+
+		offset = nextOffset
+	}
+
+	return nil, errors.New("target index out of range")
+}
+
+// ParseWinZipAESInfoAt extracts WinZipAESInfo for the central directory entry at ordinal
+// position targetIndex. It returns an error if that entry isn't WinZip AES encrypted (method 99
+// with a local 0x9901 extra field), which callers use to fall back to ZipCrypto or yeka/zip.
+func ParseWinZipAESInfoAt(zipBytes []byte, targetIndex int) (*WinZipAESInfo, error) {
+	if len(zipBytes) < 22 {
+		return nil, errors.New("zip file too small")
+	}
+
+	eocdOffset := findEOCD(zipBytes)
+	if eocdOffset == -1 {
+		return nil, errors.New("end of central directory not found")
+	}
+
+	cdOffset := binary.LittleEndian.Uint32(zipBytes[eocdOffset+16:])
+	numEntries := binary.LittleEndian.Uint16(zipBytes[eocdOffset+10:])
+
+	if cdOffset >= uint32(len(zipBytes)) {
+		return nil, errors.New("invalid central directory offset")
+	}
+
+	offset := cdOffset
+	for i := uint16(0); i < numEntries && offset < uint32(len(zipBytes)-46); i++ {
+		if binary.LittleEndian.Uint32(zipBytes[offset:]) != 0x02014b50 {
+			return nil, errors.New("invalid central directory entry")
+		}
+
+		flag := binary.LittleEndian.Uint16(zipBytes[offset+8:])
+		method := binary.LittleEndian.Uint16(zipBytes[offset+10:])
+		fileNameLen := binary.LittleEndian.Uint16(zipBytes[offset+28:])
+		extraLen := binary.LittleEndian.Uint16(zipBytes[offset+30:])
+		commentLen := binary.LittleEndian.Uint16(zipBytes[offset+32:])
+		localHeaderOffset := binary.LittleEndian.Uint32(zipBytes[offset+42:])
+
+		nextOffset := offset + 46 + uint32(fileNameLen) + uint32(extraLen) + uint32(commentLen)
+
+		if int(i) == targetIndex {
+			if (flag&0x01) == 0 || method != 99 {
+				return nil, errors.New("target entry is not WinZip AES encrypted")
+			}
+			if localHeaderOffset >= uint32(len(zipBytes)-30) {
+				return nil, errors.New("invalid local header offset")
+			}
+			return extractWinZipAESInfo(zipBytes, localHeaderOffset)
+		}
+
+		offset = nextOffset
+	}
+
+	return nil, errors.New("target index out of range")
+}
+
+// parseWinZipAESHeaders scans the ZIP's central directory and returns WinZipAESInfo for the
+// smallest WinZip AE-1/AE-2 encrypted entry (method 99 with a 0x9901 extra field), mirroring
+// parseZipHeaders's selection of the smallest traditional ZipCrypto entry. vulkan.go's
+// detectShaderVariant/newWorkerOnQueue use this when the target entry isn't ZipCrypto.
+func parseWinZipAESHeaders(zipBytes []byte) (*WinZipAESInfo, error) {
+	if len(zipBytes) < 22 {
+		return nil, errors.New("zip file too small")
+	}
+
+	eocdOffset := findEOCD(zipBytes)
+	if eocdOffset == -1 {
+		return nil, errors.New("end of central directory not found")
+	}
+
+	cdOffset := binary.LittleEndian.Uint32(zipBytes[eocdOffset+16:])
+	numEntries := binary.LittleEndian.Uint16(zipBytes[eocdOffset+10:])
+
+	if cdOffset >= uint32(len(zipBytes)) {
+		return nil, errors.New("invalid central directory offset")
+	}
+
+	var bestInfo *WinZipAESInfo
+	var bestSize uint64 = ^uint64(0) // max value
+
+	offset := cdOffset
+	for i := uint16(0); i < numEntries && offset < uint32(len(zipBytes)-46); i++ {
+		if binary.LittleEndian.Uint32(zipBytes[offset:]) != 0x02014b50 {
+			return nil, errors.New("invalid central directory entry")
+		}
+
+		flag := binary.LittleEndian.Uint16(zipBytes[offset+8:])
+		method := binary.LittleEndian.Uint16(zipBytes[offset+10:])
+		uncompressedSize := binary.LittleEndian.Uint32(zipBytes[offset+24:])
+		fileNameLen := binary.LittleEndian.Uint16(zipBytes[offset+28:])
+		extraLen := binary.LittleEndian.Uint16(zipBytes[offset+30:])
+		commentLen := binary.LittleEndian.Uint16(zipBytes[offset+32:])
+		localHeaderOffset := binary.LittleEndian.Uint32(zipBytes[offset+42:])
+
+		nextOffset := offset + 46 + uint32(fileNameLen) + uint32(extraLen) + uint32(commentLen)
+
+		// Check if this is an encrypted entry (bit 0 of flag) using WinZip AES (method 99).
+		if (flag&0x01) != 0 && method == 99 {
+			if localHeaderOffset < uint32(len(zipBytes)-30) {
+				info, err := extractWinZipAESInfo(zipBytes, localHeaderOffset)
+				if err == nil && uint64(uncompressedSize) < bestSize {
+					bestInfo = info
+					bestSize = uint64(uncompressedSize)
+				}
+			}
+		}
+
+		offset = nextOffset
+	}
+
+	if bestInfo == nil {
+		return nil, errors.New("no suitable WinZip AES entries found")
+	}
+
+	return bestInfo, nil
+}
+
+// extractWinZipAESInfo reads the local file header's 0x9901 extra field to determine the AES
+// strength, then reads the PBKDF2 salt and password verification value that immediately follow
+// the local header + filename + extra field in the entry's data.
+func extractWinZipAESInfo(zipBytes []byte, localHeaderOffset uint32) (*WinZipAESInfo, error) {
+	if localHeaderOffset+30 > uint32(len(zipBytes)) {
+		return nil, errors.New("invalid local header offset")
+	}
+	if binary.LittleEndian.Uint32(zipBytes[localHeaderOffset:]) != 0x04034b50 {
+		return nil, errors.New("invalid local file header")
+	}
+
+	fileNameLen := binary.LittleEndian.Uint16(zipBytes[localHeaderOffset+26:])
+	extraLen := binary.LittleEndian.Uint16(zipBytes[localHeaderOffset+28:])
+	extraStart := localHeaderOffset + 30 + uint32(fileNameLen)
+	extraEnd := extraStart + uint32(extraLen)
+	if extraEnd > uint32(len(zipBytes)) {
+		return nil, errors.New("invalid local extra field")
+	}
+
+	var strength byte
+	found := false
+	for p := extraStart; p+4 <= extraEnd; {
+		tag := binary.LittleEndian.Uint16(zipBytes[p:])
+		size := binary.LittleEndian.Uint16(zipBytes[p+2:])
+		if tag == 0x9901 && size >= 7 && p+4+uint32(size) <= extraEnd {
+			// Payload: VendorVersion(2) VendorID(2) Strength(1) CompressionMethod(2)
+			strength = zipBytes[p+4+4]
+			found = true
+			break
+		}
+		p += 4 + uint32(size)
+	}
+	if !found {
+		return nil, errors.New("no AES extra field found")
+	}
+
+	saltLen := aesSaltLen(strength)
+	if saltLen == 0 {
+		return nil, errors.New("unrecognized AES strength")
+	}
+
+	dataOffset := extraEnd
+	if dataOffset+uint32(saltLen)+2 > uint32(len(zipBytes)) {
+		return nil, errors.New("insufficient data for AES salt and password verification value")
+	}
+
+	salt := make([]byte, saltLen)
+	copy(salt, zipBytes[dataOffset:dataOffset+uint32(saltLen)])
+
+	var pv [2]byte
+	copy(pv[:], zipBytes[dataOffset+uint32(saltLen):dataOffset+uint32(saltLen)+2])
+
+	return &WinZipAESInfo{Strength: strength, Salt: salt, PasswordVerify: pv}, nil
+}
+
+// ExtractCiphertext returns length bytes of an entry's raw on-disk data (still compressed, still
+// encrypted), starting offset bytes past the beginning of the entry's 12-byte ZipCrypto
+// encryption header. It performs no decryption or interpretation: callers doing known-plaintext
+// cryptanalysis (see internal/plaintext) XOR these bytes against known plaintext themselves to
+// obtain keystream.
+func ExtractCiphertext(zipBytes []byte, targetIndex, offset, length int) ([]byte, error) {
+	if len(zipBytes) < 22 {
+		return nil, errors.New("zip file too small")
+	}
+
+	eocdOffset := findEOCD(zipBytes)
+	if eocdOffset == -1 {
+		return nil, errors.New("end of central directory not found")
+	}
+
+	cdOffset := binary.LittleEndian.Uint32(zipBytes[eocdOffset+16:])
+	numEntries := binary.LittleEndian.Uint16(zipBytes[eocdOffset+10:])
+
+	if cdOffset >= uint32(len(zipBytes)) {
+		return nil, errors.New("invalid central directory offset")
+	}
+
+	cdEntryOffset := cdOffset
+	for i := uint16(0); i < numEntries && cdEntryOffset < uint32(len(zipBytes)-46); i++ {
+		if binary.LittleEndian.Uint32(zipBytes[cdEntryOffset:]) != 0x02014b50 {
+			return nil, errors.New("invalid central directory entry")
+		}
+
+		compressedSize := binary.LittleEndian.Uint32(zipBytes[cdEntryOffset+20:])
+		fileNameLen := binary.LittleEndian.Uint16(zipBytes[cdEntryOffset+28:])
+		extraLen := binary.LittleEndian.Uint16(zipBytes[cdEntryOffset+30:])
+		commentLen := binary.LittleEndian.Uint16(zipBytes[cdEntryOffset+32:])
+		localHeaderOffset := binary.LittleEndian.Uint32(zipBytes[cdEntryOffset+42:])
+
+		nextOffset := cdEntryOffset + 46 + uint32(fileNameLen) + uint32(extraLen) + uint32(commentLen)
+
+		if int(i) == targetIndex {
+			if localHeaderOffset+30 > uint32(len(zipBytes)) {
+				return nil, errors.New("invalid local header offset")
+			}
+			if binary.LittleEndian.Uint32(zipBytes[localHeaderOffset:]) != 0x04034b50 {
+				return nil, errors.New("invalid local file header")
+			}
+			localNameLen := binary.LittleEndian.Uint16(zipBytes[localHeaderOffset+26:])
+			localExtraLen := binary.LittleEndian.Uint16(zipBytes[localHeaderOffset+28:])
+			dataStart := localHeaderOffset + 30 + uint32(localNameLen) + uint32(localExtraLen)
+
+			if offset < 0 || length < 0 || uint32(offset+length) > compressedSize {
+				return nil, errors.New("requested range exceeds entry's compressed size")
+			}
+			start := dataStart + uint32(offset)
+			end := start + uint32(length)
+			if end > uint32(len(zipBytes)) {
+				return nil, errors.New("requested range exceeds zip data")
+			}
+			out := make([]byte, length)
+			copy(out, zipBytes[start:end])
+			return out, nil
+		}
+
+		cdEntryOffset = nextOffset
+	}
+
+	return nil, errors.New("target index out of range")
+}
+
 // findEOCD searches for the End of Central Directory record signature
 func findEOCD(zipBytes []byte) int {
 	// Search backwards from the end for EOCD signature (0x06054b50)